@@ -0,0 +1,148 @@
+// Package api implements a client for the Site24x7 REST API (https://www.site24x7.com/help/api/).
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
+)
+
+// Config configures a Client.
+type Config struct {
+	// BaseURL is the root of the API, e.g. https://www.site24x7.eu/api. If empty, the API of
+	// oauth.DefaultDataCenter is used.
+	BaseURL string
+
+	// HTTPClient is used to send requests. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// Authenticator, if set, authorizes every request with its access token. Otherwise HTTPClient is
+	// expected to take care of authorization.
+	Authenticator *oauth.Authenticator
+
+	// UserAgent is sent with every request if set.
+	UserAgent string
+
+	// MaxRetries is the number of times requests failing with a transient error are retried. See RetryTransport.
+	MaxRetries int
+}
+
+// Client is a Site24x7 API client. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	userAgent  string
+}
+
+// NewClient returns a client for the API described by cfg.
+func NewClient(cfg Config) *Client {
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if cfg.MaxRetries > 0 || cfg.Authenticator != nil {
+		transport := httpClient.Transport
+		if cfg.MaxRetries > 0 {
+			transport = &RetryTransport{
+				Base:       transport,
+				MaxRetries: cfg.MaxRetries,
+			}
+		}
+		if cfg.Authenticator != nil {
+			transport = &oauth.Transport{
+				Base:          transport,
+				Authenticator: cfg.Authenticator,
+			}
+		}
+		c := *httpClient
+		c.Transport = transport
+		httpClient = &c
+	}
+
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = oauth.DataCenters[oauth.DefaultDataCenter].APIBaseURL
+	}
+
+	return &Client{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		userAgent:  cfg.UserAgent,
+	}
+}
+
+// BaseURL returns the root of the API the client talks to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, r)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json; version=2.0")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return req, nil
+}
+
+// do sends a request with the JSON encoding of body (if not nil) and decodes the data field of the response into
+// data (if not nil).
+func (c *Client) do(method, path string, body, data interface{}) error {
+	req, err := c.newRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(req, resp, path)
+	}
+	if data == nil {
+		return nil
+	}
+
+	apiResp := struct {
+		Data interface{} `json:"data"`
+	}{Data: data}
+	return json.NewDecoder(resp.Body).Decode(&apiResp)
+}
+
+func (c *Client) get(path string, data interface{}) error {
+	return c.do(http.MethodGet, path, nil, data)
+}
+
+func (c *Client) post(path string, body, data interface{}) error {
+	return c.do(http.MethodPost, path, body, data)
+}
+
+func (c *Client) put(path string, body, data interface{}) error {
+	return c.do(http.MethodPut, path, body, data)
+}
+
+func (c *Client) delete(path string) error {
+	return c.do(http.MethodDelete, path, nil, nil)
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientGetWebsiteMonitor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/monitors/123" {
+			t.Errorf("got path %s, want /api/monitors/123", r.URL.Path)
+		}
+		if got := r.Header.Get("User-Agent"); got != "test-agent" {
+			t.Errorf("got user agent %q, want test-agent", got)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":    0,
+			"message": "success",
+			"data": map[string]interface{}{
+				"monitor_id":   "123",
+				"display_name": "test",
+				"type":         "URL",
+			},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewClient(Config{BaseURL: srv.URL + "/api/", UserAgent: "test-agent"})
+	m, err := c.GetWebsiteMonitor("123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MonitorID != "123" || m.DisplayName != "test" {
+		t.Errorf("got monitor %+v", m)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    int
+	}{
+		{
+			name:        "JSON",
+			status:      http.StatusBadRequest,
+			body:        `{"error_code":1001,"message":"Invalid monitor type","error_info":{"type":"required"}}`,
+			wantMessage: "Invalid monitor type",
+			wantCode:    1001,
+		},
+		{
+			name:        "HTML",
+			status:      http.StatusBadGateway,
+			body:        "<html><body>Bad Gateway</body></html>",
+			wantMessage: "<html><body>Bad Gateway</body></html>",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient(Config{BaseURL: srv.URL})
+			_, err := c.CreateWebsiteMonitor(&WebsiteMonitor{})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != test.status || apiErr.Message != test.wantMessage || apiErr.ErrorCode != test.wantCode {
+				t.Errorf("got %+v", apiErr)
+			}
+			if apiErr.Method != http.MethodPost || apiErr.Path != "/monitors" {
+				t.Errorf("got request %s %s, want POST /monitors", apiErr.Method, apiErr.Path)
+			}
+		})
+	}
+}
+
+func TestErrorHelpers(t *testing.T) {
+	notFound := fmt.Errorf("reading monitor: %w", &APIError{StatusCode: http.StatusNotFound})
+	if !IsNotFound(notFound) || IsAuth(notFound) || IsRateLimited(notFound) {
+		t.Errorf("wrong classification of %v", notFound)
+	}
+	if !IsAuth(&APIError{StatusCode: http.StatusUnauthorized}) {
+		t.Error("401 not classified as auth error")
+	}
+	if !IsRateLimited(&APIError{StatusCode: http.StatusTooManyRequests}) {
+		t.Error("429 not classified as rate limited")
+	}
+	if IsNotFound(errors.New("not found")) {
+		t.Error("plain error classified as not found")
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// APIError is an error response from the Site24x7 API.
+type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Method and Path identify the request that failed, Path being relative to the API base URL.
+	Method string
+	Path   string
+
+	// ErrorCode, Message and ErrorInfo are the fields of the error response. Message holds the start of the
+	// response body if it wasn't JSON, e.g. the HTML page of a failing proxy.
+	ErrorCode int
+	Message   string
+	ErrorInfo json.RawMessage
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: ", e.Method, e.Path)
+	if e.Message != "" {
+		b.WriteString(e.Message)
+	} else {
+		b.WriteString(http.StatusText(e.StatusCode))
+	}
+	if len(e.ErrorInfo) != 0 && string(e.ErrorInfo) != "null" {
+		fmt.Fprintf(&b, " (%s)", e.ErrorInfo)
+	}
+	if e.ErrorCode != 0 {
+		fmt.Fprintf(&b, " [HTTP %d, error code %d]", e.StatusCode, e.ErrorCode)
+	} else {
+		fmt.Fprintf(&b, " [HTTP %d]", e.StatusCode)
+	}
+	return b.String()
+}
+
+// IsNotFound reports whether err is an APIError for a resource that doesn't exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsRateLimited reports whether err is an APIError for a request rejected because of throttling.
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsAuth reports whether err is an APIError for a request rejected because of a missing or insufficient access
+// token.
+func IsAuth(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// maxErrorBodySize bounds how much of a non-JSON error response is kept in the message.
+const maxErrorBodySize = 512
+
+// newAPIError builds an APIError from the failed response to req.
+func newAPIError(req *http.Request, resp *http.Response, path string) error {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Path:       path,
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("%s %s: reading error response: %v [HTTP %d]", req.Method, path, err, resp.StatusCode)
+	}
+
+	var data struct {
+		ErrorCode int             `json:"error_code"`
+		Message   string          `json:"message"`
+		ErrorInfo json.RawMessage `json:"error_info"`
+	}
+	if err := json.Unmarshal(body, &data); err == nil {
+		apiErr.ErrorCode = data.ErrorCode
+		apiErr.Message = data.Message
+		apiErr.ErrorInfo = data.ErrorInfo
+	} else {
+		text := strings.TrimSpace(string(body))
+		if len(text) > maxErrorBodySize {
+			text = text[:maxErrorBodySize] + "..."
+		}
+		apiErr.Message = text
+	}
+	return apiErr
+}
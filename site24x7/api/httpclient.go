@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout        = 30 * time.Second
+	defaultConnectTimeout = 10 * time.Second
+)
+
+// HTTPOptions configures the HTTP client built by NewHTTPClient.
+type HTTPOptions struct {
+	// Timeout bounds each attempt of a request, including reading the response body. It defaults to 30 seconds.
+	// Waiting between retries or for a request limit doesn't count, as transports doing so wrap the client's.
+	Timeout time.Duration
+
+	// ConnectTimeout bounds establishing a connection, including the TLS handshake. It defaults to 10 seconds.
+	ConnectTimeout time.Duration
+
+	// CAFile is a PEM file of certificate authorities trusted in addition to the system ones, e.g. the one of a
+	// TLS-intercepting proxy.
+	CAFile string
+
+	// Insecure disables certificate verification. It is meant for debugging only.
+	Insecure bool
+}
+
+// NewHTTPClient returns an HTTP client for talking to Site24x7 and Zoho, meant to be shared by the API client and the
+// oauth.Authenticator. Requests go through the proxy named by the HTTPS_PROXY and NO_PROXY environment variables.
+func NewHTTPClient(opts HTTPOptions) (*http.Client, error) {
+	timeout, connectTimeout := opts.Timeout, opts.ConnectTimeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	if connectTimeout <= 0 {
+		connectTimeout = defaultConnectTimeout
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: opts.Insecure,
+	}
+	if opts.CAFile != "" {
+		pem, err := ioutil.ReadFile(opts.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in CA file %s", opts.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	// http.Client.Timeout would also bound the retries and waits of the transports wrapping this one
+	return &http.Client{
+		Transport: &timeoutTransport{
+			base: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   connectTimeout,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				TLSClientConfig:       tlsConfig,
+				TLSHandshakeTimeout:   connectTimeout,
+				MaxIdleConns:          100,
+				IdleConnTimeout:       90 * time.Second,
+				ExpectContinueTimeout: time.Second,
+			},
+			timeout: timeout,
+		},
+	}, nil
+}
+
+// timeoutTransport is an http.RoundTripper that bounds each request it makes by timeout, until the response body
+// is closed.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases the context of a request when its response body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
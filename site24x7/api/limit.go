@@ -0,0 +1,103 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// LimitTransport is an http.RoundTripper that limits the number of concurrent requests and the number of requests
+// started per minute, making requests wait until they are allowed. The zero value of a limit disables it.
+type LimitTransport struct {
+	// Base is the transport used to make requests. If nil, http.DefaultTransport is used. Requests should be timed
+	// out by Base, e.g. the transport of a client built by NewHTTPClient, rather than by an http.Client wrapping the
+	// LimitTransport, so that the time waiting for the limit doesn't count against the timeout.
+	Base http.RoundTripper
+
+	// MaxConcurrent is the maximum number of requests in flight.
+	MaxConcurrent int
+
+	// RequestsPerMinute is the maximum number of requests started in any period of a minute.
+	RequestsPerMinute int
+
+	// window is the period RequestsPerMinute applies to, a minute unless set by tests.
+	window time.Duration
+
+	once sync.Once
+	sem  chan struct{}
+
+	mu      sync.Mutex
+	started []time.Time // start times of the last RequestsPerMinute requests, oldest first
+}
+
+func (t *LimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.once.Do(func() {
+		if t.MaxConcurrent > 0 {
+			t.sem = make(chan struct{}, t.MaxConcurrent)
+		}
+		if t.window <= 0 {
+			t.window = time.Minute
+		}
+	})
+
+	start := time.Now()
+	if t.sem != nil {
+		select {
+		case t.sem <- struct{}{}:
+			defer func() { <-t.sem }()
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	if err := t.waitRate(req); err != nil {
+		return nil, err
+	}
+	if wait := time.Since(start); wait >= time.Millisecond {
+		log.Printf("[DEBUG] %s %s waited %s for the request limit", req.Method, req.URL.Path, wait.Round(time.Millisecond))
+	}
+
+	return t.base().RoundTrip(req)
+}
+
+// waitRate waits until starting another request doesn't exceed RequestsPerMinute and records its start.
+func (t *LimitTransport) waitRate(req *http.Request) error {
+	if t.RequestsPerMinute <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	now := time.Now()
+	next := now
+	if len(t.started) >= t.RequestsPerMinute {
+		// the request may start once the oldest of the last RequestsPerMinute requests is a window ago
+		next = t.started[len(t.started)-t.RequestsPerMinute].Add(t.window)
+		if next.Before(now) {
+			next = now
+		}
+	}
+	// reserve the slot before waiting, so that concurrent requests queue up behind it
+	t.started = append(t.started, next)
+	if len(t.started) > t.RequestsPerMinute {
+		t.started = t.started[len(t.started)-t.RequestsPerMinute:]
+	}
+	t.mu.Unlock()
+
+	if wait := time.Until(next); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-req.Context().Done():
+			return req.Context().Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+func (t *LimitTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
@@ -0,0 +1,404 @@
+package api
+
+import "sort"
+
+type ValueAndSeverity struct {
+	Value    string `json:"value"`
+	Severity Status `json:"severity"`
+}
+
+type Header struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ActionRef struct {
+	ActionID  string `json:"action_id"`
+	AlertType Status `json:"alert_type"`
+}
+
+// Monitor holds the attributes common to all monitor types.
+type Monitor struct {
+	MonitorID   string `json:"monitor_id"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+}
+
+type WebsiteMonitor struct {
+	MonitorID             string            `json:"monitor_id,omitempty"`
+	DisplayName           string            `json:"display_name"`
+	Type                  string            `json:"type"`
+	Website               string            `json:"website"`
+	CheckFrequency        string            `json:"check_frequency"`
+	HTTPMethod            string            `json:"http_method"`
+	AuthUser              string            `json:"auth_user"`
+	AuthPass              *string           `json:"auth_pass,omitempty"`
+	MatchingKeyword       *ValueAndSeverity `json:"matching_keyword,omitempty"`
+	UnmatchingKeyword     *ValueAndSeverity `json:"unmatching_keyword,omitempty"`
+	MatchRegex            *ValueAndSeverity `json:"match_regex,omitempty"`
+	MatchCase             bool              `json:"match_case"`
+	UserAgent             string            `json:"user_agent"`
+	CustomHeaders         []Header          `json:"custom_headers"`
+	Timeout               int               `json:"timeout"`
+	LocationProfileID     string            `json:"location_profile_id"`
+	NotificationProfileID string            `json:"notification_profile_id"`
+	ThresholdProfileID    string            `json:"threshold_profile_id"`
+	MonitorGroups         []string          `json:"monitor_groups"`
+	UserGroupIDs          []string          `json:"user_group_ids"`
+	ActionIDs             []ActionRef       `json:"action_ids"`
+	UseNameServer         bool              `json:"use_name_server"`
+}
+
+// Authentication methods of REST API monitors.
+const (
+	AuthNone   = ""
+	AuthBasic  = "B"
+	AuthBearer = "T"
+	AuthOAuth2 = "O"
+)
+
+// Content types of REST API requests and responses.
+const (
+	ContentTypeJSON = "J"
+	ContentTypeXML  = "X"
+	ContentTypeText = "T"
+	ContentTypeForm = "F"
+)
+
+// PathAssertion is a JSONPath or XPath expression that must match the response of a REST API monitor.
+type PathAssertion struct {
+	Expression string `json:"name"`
+	Severity   Status `json:"severity"`
+}
+
+// JSONSchema is a JSON schema the response of a REST API monitor must conform to.
+type JSONSchema struct {
+	SchemaValue string `json:"schema_value"`
+	Severity    Status `json:"severity"`
+}
+
+// OAuth2ClientCredentials configures a REST API monitor to authorize requests with an access token obtained with
+// the OAuth2 client credentials grant.
+type OAuth2ClientCredentials struct {
+	GrantType    string  `json:"grant_type"`
+	TokenURL     string  `json:"token_url"`
+	ClientID     string  `json:"client_id"`
+	ClientSecret *string `json:"client_secret,omitempty"`
+	Scope        string  `json:"scope,omitempty"`
+}
+
+type RestAPIMonitor struct {
+	MonitorID             string                   `json:"monitor_id,omitempty"`
+	DisplayName           string                   `json:"display_name"`
+	Type                  string                   `json:"type"`
+	Website               string                   `json:"website"`
+	CheckFrequency        string                   `json:"check_frequency"`
+	Timeout               int                      `json:"timeout"`
+	HTTPMethod            string                   `json:"http_method"`
+	RequestContentType    string                   `json:"request_content_type,omitempty"`
+	RequestParam          string                   `json:"request_param,omitempty"`
+	CustomHeaders         []Header                 `json:"custom_headers"`
+	UserAgent             string                   `json:"user_agent"`
+	AuthMethod            string                   `json:"auth_method"`
+	AuthUser              string                   `json:"auth_user"`
+	AuthPass              *string                  `json:"auth_pass,omitempty"`
+	BearerToken           *string                  `json:"bearer_token,omitempty"`
+	OAuth2                *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+	ResponseContentType   string                   `json:"response_content_type,omitempty"`
+	MatchJSON             []PathAssertion          `json:"match_json"`
+	MatchXML              []PathAssertion          `json:"match_xml"`
+	JSONSchemaCheck       bool                     `json:"json_schema_check"`
+	JSONSchema            *JSONSchema              `json:"json_schema,omitempty"`
+	UpStatusCodes         string                   `json:"up_status_codes"`
+	LocationProfileID     string                   `json:"location_profile_id"`
+	NotificationProfileID string                   `json:"notification_profile_id"`
+	ThresholdProfileID    string                   `json:"threshold_profile_id"`
+	MonitorGroups         []string                 `json:"monitor_groups"`
+	UserGroupIDs          []string                 `json:"user_group_ids"`
+	ActionIDs             []ActionRef              `json:"action_ids"`
+	UseNameServer         bool                     `json:"use_name_server"`
+}
+
+// Sources of the values of response variables.
+const (
+	VariableSourceJSONPath = "J"
+	VariableSourceRegex    = "R"
+	VariableSourceHeader   = "H"
+)
+
+// ResponseVariable extracts a value from the response of a REST API transaction step, for use in the requests of
+// later steps as ${name}.
+type ResponseVariable struct {
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	Expression string `json:"expression"`
+}
+
+// RestAPITransactionStep is one request of a REST API transaction monitor.
+type RestAPITransactionStep struct {
+	StepOrder           int                `json:"step_order"`
+	DisplayName         string             `json:"display_name"`
+	StepURL             string             `json:"step_url"`
+	HTTPMethod          string             `json:"http_method"`
+	RequestContentType  string             `json:"request_content_type,omitempty"`
+	RequestParam        string             `json:"request_param,omitempty"`
+	CustomHeaders       []Header           `json:"custom_headers"`
+	Timeout             int                `json:"timeout"`
+	ResponseContentType string             `json:"response_content_type,omitempty"`
+	MatchJSON           []PathAssertion    `json:"match_json"`
+	MatchXML            []PathAssertion    `json:"match_xml"`
+	UpStatusCodes       string             `json:"up_status_codes"`
+	ResponseVariables   []ResponseVariable `json:"response_variables"`
+}
+
+type RestAPITransactionMonitor struct {
+	MonitorID             string                   `json:"monitor_id,omitempty"`
+	DisplayName           string                   `json:"display_name"`
+	Type                  string                   `json:"type"`
+	CheckFrequency        string                   `json:"check_frequency"`
+	Steps                 []RestAPITransactionStep `json:"steps"`
+	LocationProfileID     string                   `json:"location_profile_id"`
+	NotificationProfileID string                   `json:"notification_profile_id"`
+	ThresholdProfileID    string                   `json:"threshold_profile_id"`
+	MonitorGroups         []string                 `json:"monitor_groups"`
+	UserGroupIDs          []string                 `json:"user_group_ids"`
+	ActionIDs             []ActionRef              `json:"action_ids"`
+	UseNameServer         bool                     `json:"use_name_server"`
+}
+
+type SSLCertificateMonitor struct {
+	MonitorID             string      `json:"monitor_id,omitempty"`
+	DisplayName           string      `json:"display_name"`
+	Type                  string      `json:"type"`
+	DomainName            string      `json:"domain_name"`
+	Port                  int         `json:"port"`
+	Timeout               int         `json:"timeout"`
+	CheckFrequency        string      `json:"check_frequency"`
+	ExpireDays            int         `json:"expire_days"`
+	ProtocolVersions      []string    `json:"protocol_versions"`
+	CertFingerprint       string      `json:"cert_fingerprint,omitempty"`
+	IssuerName            string      `json:"issuer_name,omitempty"`
+	IgnoreTrust           bool        `json:"ignore_trust"`
+	IgnoreDomainMismatch  bool        `json:"ignore_domain_mismatch"`
+	LocationProfileID     string      `json:"location_profile_id"`
+	NotificationProfileID string      `json:"notification_profile_id"`
+	ThresholdProfileID    string      `json:"threshold_profile_id"`
+	MonitorGroups         []string    `json:"monitor_groups"`
+	UserGroupIDs          []string    `json:"user_group_ids"`
+	ActionIDs             []ActionRef `json:"action_ids"`
+}
+
+type DomainExpiryMonitor struct {
+	MonitorID             string      `json:"monitor_id,omitempty"`
+	DisplayName           string      `json:"display_name"`
+	Type                  string      `json:"type"`
+	DomainName            string      `json:"domain_name"`
+	WhoisServer           string      `json:"whois_server,omitempty"`
+	Port                  int         `json:"port"`
+	Timeout               int         `json:"timeout"`
+	CheckFrequency        string      `json:"check_frequency"`
+	ExpireDays            int         `json:"expire_days"`
+	ExpireDaysDown        int         `json:"expire_days_down"`
+	RegistrarChangeCheck  bool        `json:"registrar_change_check"`
+	Registrar             string      `json:"registrar,omitempty"`
+	NameServerChangeCheck bool        `json:"name_server_change_check"`
+	ChangeSeverity        Status      `json:"change_severity"`
+	LocationProfileID     string      `json:"location_profile_id"`
+	NotificationProfileID string      `json:"notification_profile_id"`
+	ThresholdProfileID    string      `json:"threshold_profile_id"`
+	MonitorGroups         []string    `json:"monitor_groups"`
+	UserGroupIDs          []string    `json:"user_group_ids"`
+	ActionIDs             []ActionRef `json:"action_ids"`
+}
+
+// DNSRecordTypes maps DNS record types to the codes DNS server monitors use for them.
+var DNSRecordTypes = map[string]int{
+	"A":      1,
+	"NS":     2,
+	"CNAME":  5,
+	"SOA":    6,
+	"PTR":    12,
+	"MX":     15,
+	"TXT":    16,
+	"AAAA":   28,
+	"SRV":    33,
+	"DS":     43,
+	"DNSKEY": 48,
+	"CAA":    257,
+}
+
+// DNSSearchConfig is a value a DNS server monitor expects among the records returned by its lookup.
+type DNSSearchConfig struct {
+	// Addr is the value of the record, e.g. an IP address, a host name or a TXT string.
+	Addr string `json:"addr"`
+	// TTL is the expected TTL of the record in seconds, or 0 if any TTL is accepted.
+	TTL      int    `json:"ttl,omitempty"`
+	Severity Status `json:"severity"`
+}
+
+type DNSServerMonitor struct {
+	MonitorID             string            `json:"monitor_id,omitempty"`
+	DisplayName           string            `json:"display_name"`
+	Type                  string            `json:"type"`
+	DNSHost               string            `json:"dns_host"`
+	DNSPort               int               `json:"dns_port"`
+	DomainName            string            `json:"domain_name"`
+	LookupType            int               `json:"lookup_type"`
+	SearchConfig          []DNSSearchConfig `json:"search_config"`
+	DNSSEC                bool              `json:"dnssec"`
+	Timeout               int               `json:"timeout"`
+	CheckFrequency        string            `json:"check_frequency"`
+	LocationProfileID     string            `json:"location_profile_id"`
+	NotificationProfileID string            `json:"notification_profile_id"`
+	ThresholdProfileID    string            `json:"threshold_profile_id"`
+	MonitorGroups         []string          `json:"monitor_groups"`
+	UserGroupIDs          []string          `json:"user_group_ids"`
+	ActionIDs             []ActionRef       `json:"action_ids"`
+}
+
+// ListMonitors returns all monitors of the account.
+func (c *Client) ListMonitors() ([]*Monitor, error) {
+	var monitors []*Monitor
+	if err := c.get("/monitors", &monitors); err != nil {
+		return nil, err
+	}
+	return monitors, nil
+}
+
+// GetMonitor returns the id, name and type of the monitor with the given id, whatever its type.
+func (c *Client) GetMonitor(id string) (*Monitor, error) {
+	var m Monitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// MonitorExists reports whether the monitor with the given id exists.
+func (c *Client) MonitorExists(id string) (bool, error) {
+	var m Monitor
+	err := c.get("/monitors/"+id, &m)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// DeleteMonitor deletes the monitor with the given id, whatever its type.
+func (c *Client) DeleteMonitor(id string) error {
+	return c.delete("/monitors/" + id)
+}
+
+func (c *Client) GetWebsiteMonitor(id string) (*WebsiteMonitor, error) {
+	var m WebsiteMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateWebsiteMonitor creates m and returns its id.
+func (c *Client) CreateWebsiteMonitor(m *WebsiteMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateWebsiteMonitor(id string, m *WebsiteMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+func (c *Client) GetRestAPIMonitor(id string) (*RestAPIMonitor, error) {
+	var m RestAPIMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateRestAPIMonitor creates m and returns its id.
+func (c *Client) CreateRestAPIMonitor(m *RestAPIMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateRestAPIMonitor(id string, m *RestAPIMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+// GetRestAPITransactionMonitor returns the monitor with the given id, with its steps in order.
+func (c *Client) GetRestAPITransactionMonitor(id string) (*RestAPITransactionMonitor, error) {
+	var m RestAPITransactionMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	// the API doesn't necessarily return the steps in order
+	sort.SliceStable(m.Steps, func(i, j int) bool { return m.Steps[i].StepOrder < m.Steps[j].StepOrder })
+	return &m, nil
+}
+
+// CreateRestAPITransactionMonitor creates m and returns its id.
+func (c *Client) CreateRestAPITransactionMonitor(m *RestAPITransactionMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateRestAPITransactionMonitor(id string, m *RestAPITransactionMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+func (c *Client) GetSSLCertificateMonitor(id string) (*SSLCertificateMonitor, error) {
+	var m SSLCertificateMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateSSLCertificateMonitor creates m and returns its id.
+func (c *Client) CreateSSLCertificateMonitor(m *SSLCertificateMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateSSLCertificateMonitor(id string, m *SSLCertificateMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+func (c *Client) GetDomainExpiryMonitor(id string) (*DomainExpiryMonitor, error) {
+	var m DomainExpiryMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateDomainExpiryMonitor creates m and returns its id.
+func (c *Client) CreateDomainExpiryMonitor(m *DomainExpiryMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateDomainExpiryMonitor(id string, m *DomainExpiryMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+func (c *Client) GetDNSServerMonitor(id string) (*DNSServerMonitor, error) {
+	var m DNSServerMonitor
+	if err := c.get("/monitors/"+id, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// CreateDNSServerMonitor creates m and returns its id.
+func (c *Client) CreateDNSServerMonitor(m *DNSServerMonitor) (string, error) {
+	return c.createMonitor(m)
+}
+
+func (c *Client) UpdateDNSServerMonitor(id string, m *DNSServerMonitor) error {
+	return c.put("/monitors/"+id, m, nil)
+}
+
+func (c *Client) createMonitor(m interface{}) (string, error) {
+	// only the id is decoded, because the rest of the response format is broken
+	var created Monitor
+	if err := c.post("/monitors", m, &created); err != nil {
+		return "", err
+	}
+	return created.MonitorID, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+type LocationProfile struct {
+	ProfileID       string `json:"profile_id,omitempty"`
+	ProfileName     string `json:"profile_name"`
+	PrimaryLocation string `json:"primary_location"`
+}
+
+type NotificationProfile struct {
+	ProfileID                   string `json:"profile_id,omitempty"`
+	ProfileName                 string `json:"profile_name"`
+	RcaNeeded                   bool   `json:"rca_needed"`
+	NotifyAfterExecutingActions bool   `json:"notify_after_executing_actions"`
+}
+
+type ThresholdProfile struct {
+	ProfileID   string `json:"profile_id,omitempty"`
+	ProfileName string `json:"profile_name"`
+	Type        string `json:"type"`
+}
+
+func (c *Client) ListLocationProfiles() ([]*LocationProfile, error) {
+	var profiles []*LocationProfile
+	if err := c.get("/location_profiles", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (c *Client) CreateLocationProfile(p *LocationProfile) (*LocationProfile, error) {
+	var created LocationProfile
+	if err := c.post("/location_profiles", p, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) ListNotificationProfiles() ([]*NotificationProfile, error) {
+	var profiles []*NotificationProfile
+	if err := c.get("/notification_profiles", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (c *Client) CreateNotificationProfile(p *NotificationProfile) (*NotificationProfile, error) {
+	var created NotificationProfile
+	if err := c.post("/notification_profiles", p, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) ListThresholdProfiles() ([]*ThresholdProfile, error) {
+	var profiles []*ThresholdProfile
+	if err := c.get("/threshold_profiles", &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Location is a monitoring location, for use in location profiles.
+type Location struct {
+	LocationID  string `json:"location_id"`
+	DisplayName string `json:"display_name"`
+}
+
+// ListLocations returns the monitoring locations available to the account.
+func (c *Client) ListLocations() ([]*Location, error) {
+	var template struct {
+		Locations []*Location `json:"locations"`
+	}
+	if err := c.get("/location_template", &template); err != nil {
+		return nil, err
+	}
+	return template.Locations, nil
+}
@@ -0,0 +1,64 @@
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		wantCalls int
+	}{
+		{name: "throttled POST", method: http.MethodPost, status: http.StatusTooManyRequests, wantCalls: 3},
+		{name: "failed PUT", method: http.MethodPut, status: http.StatusBadGateway, wantCalls: 3},
+		{name: "failed POST", method: http.MethodPost, status: http.StatusBadGateway, wantCalls: 1},
+		{name: "bad request", method: http.MethodPut, status: http.StatusBadRequest, wantCalls: 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if body, _ := ioutil.ReadAll(r.Body); string(body) != "payload" {
+					t.Errorf("got body %q, want payload", body)
+				}
+				if calls < 3 {
+					w.WriteHeader(test.status)
+				}
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: &RetryTransport{MaxRetries: 5, MinBackoff: time.Millisecond}}
+			req, _ := http.NewRequest(test.method, srv.URL, strings.NewReader("payload"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if calls != test.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("7"); !ok || d != 7*time.Second {
+		t.Errorf("got %s, %v for seconds, want 7s", d, ok)
+	}
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d <= 50*time.Second {
+		t.Errorf("got %s, %v for date, want about 1m", d, ok)
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Error("got ok for invalid value")
+	}
+}
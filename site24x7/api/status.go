@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Status is the status of a monitor, also used for the severity of failed checks and the alert type of actions.
+type Status int
+
+const (
+	Down           Status = 0
+	Up             Status = 1
+	Trouble        Status = 2
+	Suspended      Status = 5
+	Maintenance    Status = 7
+	Discovery      Status = 9
+	DiscoveryError Status = 10
+)
+
+var statusNames = map[Status]string{
+	Down:           "down",
+	Up:             "up",
+	Trouble:        "trouble",
+	Suspended:      "suspended",
+	Maintenance:    "maintenance",
+	Discovery:      "discovery",
+	DiscoveryError: "discovery_error",
+}
+
+// String returns the symbolic name of s, e.g. "down", or its number if it has none.
+func (s Status) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return strconv.Itoa(int(s))
+}
+
+// ParseStatus returns the status with the given symbolic name.
+func ParseStatus(name string) (Status, error) {
+	for s, n := range statusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown status %q", name)
+}
+
+// StatusNames returns the symbolic names of all statuses, sorted.
+func StatusNames() []string {
+	names := make([]string, 0, len(statusNames))
+	for _, name := range statusNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,23 @@
+package api
+
+type UserGroup struct {
+	UserGroupID string   `json:"user_group_id,omitempty"`
+	DisplayName string   `json:"display_name"`
+	Users       []string `json:"users"`
+}
+
+func (c *Client) ListUserGroups() ([]*UserGroup, error) {
+	var groups []*UserGroup
+	if err := c.get("/user_groups", &groups); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+func (c *Client) CreateUserGroup(g *UserGroup) (*UserGroup, error) {
+	var created UserGroup
+	if err := c.post("/user_groups", g, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
@@ -0,0 +1,15 @@
+package api
+
+type User struct {
+	UserID       string `json:"user_id,omitempty"`
+	DisplayName  string `json:"display_name"`
+	EmailAddress string `json:"email_address"`
+}
+
+func (c *Client) ListUsers() ([]*User, error) {
+	var users []*User
+	if err := c.get("/users", &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
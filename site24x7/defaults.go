@@ -0,0 +1,208 @@
+package site24x7
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// defaultProfileName is the name of the profiles and user group created by auto_create_default_profiles.
+const defaultProfileName = "Terraform Default"
+
+// profileDefaults provides the profiles and user groups assigned to monitors that don't specify them. They are
+// given as provider arguments or, if not, looked up in the account once per provider instance. It is safe for
+// concurrent use.
+type profileDefaults struct {
+	client *api.Client
+
+	// autoCreate enables creating a minimal location profile, notification profile and user group if the account
+	// has none.
+	autoCreate bool
+
+	mu                    sync.Mutex
+	locationProfileID     string
+	notificationProfileID string
+	thresholdProfileIDs   map[string]string // by monitor type
+	userGroupIDs          []string
+}
+
+func (p *profileDefaults) LocationProfileID() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.locationProfileID == "" {
+		profiles, err := p.client.ListLocationProfiles()
+		if err != nil {
+			return "", fmt.Errorf("error looking up default location profile: %w", err)
+		}
+		switch {
+		case len(profiles) > 0:
+			p.locationProfileID = profiles[0].ProfileID
+		case p.autoCreate:
+			if p.locationProfileID, err = p.createLocationProfile(); err != nil {
+				return "", err
+			}
+		default:
+			return "", missingDefaultError("location profile", "default_location_profile_id")
+		}
+	}
+	return p.locationProfileID, nil
+}
+
+func (p *profileDefaults) NotificationProfileID() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.notificationProfileID == "" {
+		profiles, err := p.client.ListNotificationProfiles()
+		if err != nil {
+			return "", fmt.Errorf("error looking up default notification profile: %w", err)
+		}
+		switch {
+		case len(profiles) > 0:
+			p.notificationProfileID = profiles[0].ProfileID
+		case p.autoCreate:
+			profile, err := p.client.CreateNotificationProfile(&api.NotificationProfile{
+				ProfileName:                 defaultProfileName,
+				RcaNeeded:                   true,
+				NotifyAfterExecutingActions: true,
+			})
+			if err != nil {
+				return "", fmt.Errorf("error creating default notification profile: %w", err)
+			}
+			p.notificationProfileID = profile.ProfileID
+		default:
+			return "", missingDefaultError("notification profile", "default_notification_profile_id")
+		}
+	}
+	return p.notificationProfileID, nil
+}
+
+// ThresholdProfileID returns the default threshold profile for monitors of the given API type.
+func (p *profileDefaults) ThresholdProfileID(monitorType string) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if id, ok := p.thresholdProfileIDs[monitorType]; ok {
+		return id, nil
+	}
+
+	profiles, err := p.client.ListThresholdProfiles()
+	if err != nil {
+		return "", fmt.Errorf("error looking up default threshold profile: %w", err)
+	}
+	for _, profile := range profiles {
+		if _, ok := p.thresholdProfileIDs[profile.Type]; !ok {
+			p.thresholdProfileIDs[profile.Type] = profile.ProfileID
+		}
+	}
+
+	if id, ok := p.thresholdProfileIDs[monitorType]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("no threshold profile for %s monitors found in the Site24x7 account: create one or set it in default_threshold_profile_ids", monitorType)
+}
+
+func (p *profileDefaults) UserGroupIDs() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.userGroupIDs) == 0 {
+		groups, err := p.client.ListUserGroups()
+		if err != nil {
+			return nil, fmt.Errorf("error looking up default user group: %w", err)
+		}
+		switch {
+		case len(groups) > 0:
+			p.userGroupIDs = []string{groups[0].UserGroupID}
+		case p.autoCreate:
+			id, err := p.createUserGroup()
+			if err != nil {
+				return nil, err
+			}
+			p.userGroupIDs = []string{id}
+		default:
+			return nil, missingDefaultError("user group", "default_user_group_ids")
+		}
+	}
+	return p.userGroupIDs, nil
+}
+
+// createLocationProfile creates a location profile monitoring from the first available location.
+func (p *profileDefaults) createLocationProfile() (string, error) {
+	locations, err := p.client.ListLocations()
+	if err != nil {
+		return "", fmt.Errorf("error creating default location profile: %w", err)
+	}
+	if len(locations) == 0 {
+		return "", errors.New("error creating default location profile: no monitoring locations available")
+	}
+
+	profile, err := p.client.CreateLocationProfile(&api.LocationProfile{
+		ProfileName:     defaultProfileName,
+		PrimaryLocation: locations[0].LocationID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating default location profile: %w", err)
+	}
+	return profile.ProfileID, nil
+}
+
+// createUserGroup creates a user group alerting the first user of the account, normally its owner.
+func (p *profileDefaults) createUserGroup() (string, error) {
+	users, err := p.client.ListUsers()
+	if err != nil {
+		return "", fmt.Errorf("error creating default user group: %w", err)
+	}
+	if len(users) == 0 {
+		return "", errors.New("error creating default user group: no users found")
+	}
+
+	group, err := p.client.CreateUserGroup(&api.UserGroup{
+		DisplayName: defaultProfileName,
+		Users:       []string{users[0].UserID},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating default user group: %w", err)
+	}
+	return group.UserGroupID, nil
+}
+
+// missingDefaultError returns the error for an account without any object of the given kind to use as default.
+func missingDefaultError(kind, argument string) error {
+	return fmt.Errorf("no %s found in the Site24x7 account: create one, set the provider argument %s or enable auto_create_default_profiles", kind, argument)
+}
+
+// setDefaultProfiles fills in the profile and user group ids of a monitor of the given API type that are empty with
+// the defaults, and sets the corresponding attributes of d.
+func (p *profileDefaults) setDefaultProfiles(d *schema.ResourceData, monitorType string, locationProfileID, notificationProfileID, thresholdProfileID *string, userGroupIDs *[]string) error {
+	var err error
+	if *locationProfileID == "" {
+		if *locationProfileID, err = p.LocationProfileID(); err != nil {
+			return err
+		}
+		d.Set("location_profile_id", *locationProfileID)
+	}
+	if *notificationProfileID == "" {
+		if *notificationProfileID, err = p.NotificationProfileID(); err != nil {
+			return err
+		}
+		d.Set("notification_profile_id", *notificationProfileID)
+	}
+	if *thresholdProfileID == "" {
+		if *thresholdProfileID, err = p.ThresholdProfileID(monitorType); err != nil {
+			return err
+		}
+		d.Set("threshold_profile_id", *thresholdProfileID)
+	}
+	if len(*userGroupIDs) == 0 {
+		if *userGroupIDs, err = p.UserGroupIDs(); err != nil {
+			return err
+		}
+		d.Set("user_group_ids", *userGroupIDs)
+	}
+	return nil
+}
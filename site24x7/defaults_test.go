@@ -0,0 +1,102 @@
+package site24x7
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/fake"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
+)
+
+func TestProfileDefaultsCached(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"profile_id": "1", "type": "URL"},
+				{"profile_id": "2", "type": "URL"},
+				{"profile_id": "3", "type": "SSL_CERT"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	p := &profileDefaults{
+		client:              api.NewClient(api.Config{BaseURL: srv.URL}),
+		thresholdProfileIDs: map[string]string{"DNS": "4"},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id, err := p.LocationProfileID(); err != nil || id != "1" {
+				t.Errorf("got location profile %q, %v, want 1", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for monitorType, want := range map[string]string{"URL": "1", "SSL_CERT": "3", "DNS": "4"} {
+		if id, err := p.ThresholdProfileID(monitorType); err != nil || id != want {
+			t.Errorf("got threshold profile %q, %v for %s, want %s", id, err, monitorType, want)
+		}
+	}
+
+	if requests != 2 {
+		t.Errorf("got %d requests, want 2", requests)
+	}
+}
+
+func TestProfileDefaultsEmptyAccount(t *testing.T) {
+	srv := fake.NewServer()
+	defer srv.Close()
+	for _, c := range []string{"location_profiles", "notification_profiles", "threshold_profiles", "user_groups"} {
+		srv.Clear(c)
+	}
+
+	ator, err := oauth.NewAuthenticator(fake.ClientID, fake.ClientSecret, fake.RefreshToken, oauth.Options{AccountsURL: srv.AccountsURL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL(), Authenticator: ator})
+
+	p := &profileDefaults{client: client, thresholdProfileIDs: make(map[string]string)}
+	if _, err := p.LocationProfileID(); err == nil || !strings.Contains(err.Error(), "default_location_profile_id") {
+		t.Errorf("got error %v for missing location profile", err)
+	}
+	if _, err := p.ThresholdProfileID("URL"); err == nil || !strings.Contains(err.Error(), "URL monitors") {
+		t.Errorf("got error %v for missing threshold profile", err)
+	}
+
+	p = &profileDefaults{client: client, autoCreate: true, thresholdProfileIDs: make(map[string]string)}
+	locationProfileID, err := p.LocationProfileID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile, ok := srv.Get("location_profiles", locationProfileID); !ok || profile["primary_location"] != "1" {
+		t.Errorf("got location profile %v", profile)
+	}
+	notificationProfileID, err := p.NotificationProfileID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := srv.Get("notification_profiles", notificationProfileID); !ok {
+		t.Errorf("notification profile %s not created", notificationProfileID)
+	}
+	userGroupIDs, err := p.UserGroupIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if group, ok := srv.Get("user_groups", userGroupIDs[0]); !ok || len(group["users"].([]interface{})) != 1 {
+		t.Errorf("got user group %v", group)
+	}
+}
@@ -0,0 +1,266 @@
+package site24x7
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// dnsRecordTypeNames are the record types a DNS server monitor can look up.
+var dnsRecordTypeNames = func() []string {
+	var names []string
+	for name := range api.DNSRecordTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}()
+
+func resourceSite24x7DNSServerMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: dnsServerMonitorCreate,
+		Read:   dnsServerMonitorRead,
+		Update: dnsServerMonitorUpdate,
+		Delete: dnsServerMonitorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("DNS"),
+		},
+
+		CustomizeDiff: validateDNSExpectedValues,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"dns_host": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateHost,
+				Description:  "Name server to query.",
+			},
+
+			"dns_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      53,
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+
+			"domain": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateDomainName,
+				Description:  "Name to resolve.",
+			},
+
+			"record_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "A",
+				ValidateFunc: validateStringInSlice(dnsRecordTypeNames...),
+			},
+
+			"expected_value": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateNotEmpty,
+						},
+						"ttl": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateIntBetween(0, 2147483647),
+							Description:  "Expected TTL of the record in seconds. Any TTL is accepted if 0.",
+						},
+						"severity": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      api.Down.String(),
+							ValidateFunc: validateStringInSlice(api.Down.String(), api.Trouble.String()),
+							Description:  "Status reported when the lookup doesn't return the value.",
+						},
+					},
+				},
+				Description: "Values the lookup must return. Any answer is accepted if empty.",
+			},
+
+			"dnssec": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Validate the DNSSEC signatures of the answer.",
+			},
+
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntBetween(1, 45),
+			},
+		}),
+	}
+}
+
+func dnsServerMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	return dnsServerMonitorCreateOrUpdate(d, meta)
+}
+
+func dnsServerMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	return dnsServerMonitorCreateOrUpdate(d, meta)
+}
+
+func dnsServerMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m := dnsServerMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "DNS", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateDNSServerMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating DNS server monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		if err := client.UpdateDNSServerMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating DNS server monitor %s: %w", d.Id(), err)
+		}
+	}
+
+	return readAfterWrite(d, "DNS server monitor", timeout, func() error {
+		m, err := client.GetDNSServerMonitor(d.Id())
+		if err != nil {
+			return err
+		}
+		updateDNSServerMonitorResourceData(d, m)
+		return nil
+	})
+}
+
+// dnsServerMonitorFromResourceData builds the API representation of the monitor configured in d.
+func dnsServerMonitorFromResourceData(d *schema.ResourceData) *api.DNSServerMonitor {
+	searchConfig := []api.DNSSearchConfig{}
+	for _, v := range d.Get("expected_value").([]interface{}) {
+		expected := v.(map[string]interface{})
+		// the severity was validated
+		severity, _ := api.ParseStatus(expected["severity"].(string))
+		searchConfig = append(searchConfig, api.DNSSearchConfig{
+			Addr:     expected["value"].(string),
+			TTL:      expected["ttl"].(int),
+			Severity: severity,
+		})
+	}
+
+	return &api.DNSServerMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "DNS",
+		DNSHost:               d.Get("dns_host").(string),
+		DNSPort:               d.Get("dns_port").(int),
+		DomainName:            d.Get("domain").(string),
+		LookupType:            api.DNSRecordTypes[d.Get("record_type").(string)],
+		SearchConfig:          searchConfig,
+		DNSSEC:                d.Get("dnssec").(bool),
+		Timeout:               d.Get("timeout").(int),
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		LocationProfileID:     d.Get("location_profile_id").(string),
+		NotificationProfileID: d.Get("notification_profile_id").(string),
+		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
+	}
+}
+
+// validateDNSExpectedValues checks that the expected values of A and AAAA lookups are addresses of the right family,
+// as the lookup could never return anything else.
+func validateDNSExpectedValues(d *schema.ResourceDiff, meta interface{}) error {
+	recordType := d.Get("record_type").(string)
+	if recordType != "A" && recordType != "AAAA" {
+		return nil
+	}
+	for i, v := range d.Get("expected_value").([]interface{}) {
+		value := v.(map[string]interface{})["value"].(string)
+		if value == "" {
+			// unknown until apply
+			continue
+		}
+		ip := net.ParseIP(value)
+		if ip == nil || (ip.To4() != nil) != (recordType == "A") {
+			family := "IPv4"
+			if recordType == "AAAA" {
+				family = "IPv6"
+			}
+			return fmt.Errorf("expected_value.%d.value must be an %s address for %s records, got %q", i, family, recordType, value)
+		}
+	}
+	return nil
+}
+
+func dnsServerMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m, err := client.GetDNSServerMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "DNS server monitor", err)
+	}
+	updateDNSServerMonitorResourceData(d, m)
+
+	return nil
+}
+
+func updateDNSServerMonitorResourceData(d *schema.ResourceData, m *api.DNSServerMonitor) {
+	d.Set("display_name", m.DisplayName)
+	d.Set("dns_host", m.DNSHost)
+	d.Set("dns_port", m.DNSPort)
+	d.Set("domain", m.DomainName)
+	// a lookup type this provider doesn't know is read as no record type, so that the plan shows the drift
+	recordType := ""
+	for name, code := range api.DNSRecordTypes {
+		if code == m.LookupType {
+			recordType = name
+		}
+	}
+	d.Set("record_type", recordType)
+
+	expected := make([]map[string]interface{}, len(m.SearchConfig))
+	for i, c := range m.SearchConfig {
+		expected[i] = map[string]interface{}{
+			"value":    c.Addr,
+			"ttl":      c.TTL,
+			"severity": c.Severity.String(),
+		}
+	}
+	d.Set("expected_value", expected)
+
+	d.Set("dnssec", m.DNSSEC)
+	d.Set("timeout", m.Timeout)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
+	d.Set("location_profile_id", m.LocationProfileID)
+	d.Set("notification_profile_id", m.NotificationProfileID)
+	d.Set("threshold_profile_id", m.ThresholdProfileID)
+	d.Set("monitor_groups", m.MonitorGroups)
+	d.Set("user_group_ids", m.UserGroupIDs)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
+}
+
+func dnsServerMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	return handleDeleteError(d, "DNS server monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
+}
@@ -0,0 +1,143 @@
+package site24x7
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDNSServerMonitor(t *testing.T) {
+	const config1 = `
+		resource "site24x7_dns_server_monitor" "test" {
+			display_name = "sourcegraph.com DNS"
+			dns_host = "ns1.example.com"
+			domain = "sourcegraph.com"
+		}
+	`
+
+	const config2 = `
+		resource "site24x7_dns_server_monitor" "test" {
+			display_name = "sourcegraph.com DNS"
+			dns_host = "8.8.8.8"
+			dns_port = 5353
+			domain = "_sip._tcp.sourcegraph.com"
+			record_type = "SRV"
+			dnssec = true
+			timeout = 5
+
+			expected_value {
+				value = "10 60 5060 sip.sourcegraph.com."
+				ttl = 3600
+			}
+
+			expected_value {
+				value = "20 60 5060 sip2.sourcegraph.com."
+				severity = "trouble"
+			}
+		}
+	`
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_dns_server_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_dns_server_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "dns_port", "53"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "record_type", "A"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.#", "0"),
+					resource.TestCheckResourceAttrSet("site24x7_dns_server_monitor.test", "threshold_profile_id"),
+				),
+			},
+
+			resource.TestStep{
+				Config: config2,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_dns_server_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "record_type", "SRV"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "dnssec", "true"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.#", "2"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.0.ttl", "3600"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.0.severity", "down"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.1.severity", "trouble"),
+				),
+			},
+
+			resource.TestStep{
+				Config:   config2,
+				PlanOnly: true,
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_dns_server_monitor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "expected_value.#", "0"),
+					resource.TestCheckResourceAttr("site24x7_dns_server_monitor.test", "dnssec", "false"),
+				),
+			},
+		},
+	})
+}
+
+// TestDNSServerMonitorUnknownRecordType checks that a lookup type the provider doesn't know, e.g. one changed outside
+// of Terraform, shows as drift rather than keeping the record type in the state.
+func TestDNSServerMonitorUnknownRecordType(t *testing.T) {
+	if testFakeServer == nil {
+		t.Skip("needs the fake API")
+	}
+	const config = `
+		resource "site24x7_dns_server_monitor" "test" {
+			display_name = "sourcegraph.com DNS"
+			dns_host = "8.8.8.8"
+			domain = "sourcegraph.com"
+		}
+	`
+
+	var id string
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_dns_server_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id = s.RootModule().Resources["site24x7_dns_server_monitor.test"].Primary.ID
+					return nil
+				},
+			},
+
+			resource.TestStep{
+				PreConfig: func() {
+					m, _ := testFakeServer.Get("monitors", id)
+					m["lookup_type"] = 255
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestDNSServerMonitorValidation(t *testing.T) {
+	const server = `dns_host = "8.8.8.8"` + "\n domain = \"sourcegraph.com\""
+	testValidation(t, "site24x7_dns_server_monitor", map[string]validationCase{
+		"dns_host":       {`dns_host = "dns://8.8.8.8"` + "\n domain = \"sourcegraph.com\"", `dns_host must be`},
+		"domain":         {`dns_host = "8.8.8.8"` + "\n domain = \"https://sourcegraph.com\"", `domain must be`},
+		"record_type":    {server + "\n record_type = \"ANY\"", `record_type must be`},
+		"IPv6 value":     {server + "\n record_type = \"AAAA\"\n expected_value { value = \"1.2.3.4\" }", `expected_value\.0\.value must be an IPv6 address`},
+		"IPv4 value":     {server + "\n expected_value { value = \"1.2.3.4\" }\n expected_value { value = \"example.com\" }", `expected_value\.1\.value must be an IPv4 address`},
+		"value severity": {server + "\n expected_value {\n value = \"1.2.3.4\"\n severity = \"up\"\n }", `expected_value\.0\.severity must be`},
+	})
+}
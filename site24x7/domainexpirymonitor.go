@@ -0,0 +1,225 @@
+package site24x7
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+func resourceSite24x7DomainExpiryMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: domainExpiryMonitorCreate,
+		Read:   domainExpiryMonitorRead,
+		Update: domainExpiryMonitorUpdate,
+		Delete: domainExpiryMonitorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("DOMAIN_EXPIRY"),
+		},
+
+		CustomizeDiff: validateDomainExpiryDays,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateHostname,
+			},
+
+			// registration data changes rarely, so the domain is checked daily by default
+			"check_frequency": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1440,
+				ValidateFunc: validateIntInSlice(checkFrequencies...),
+			},
+
+			"whois_server": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateHostname,
+				Description:  "WHOIS server to query instead of the one of the domain's registry.",
+			},
+
+			"whois_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      43,
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntBetween(1, 45),
+			},
+
+			"expiry_alert_days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, 365),
+				Description:  "Report the monitor as trouble this many days before the domain expires.",
+			},
+
+			"expiry_down_days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntBetween(0, 365),
+				Description:  "Report the monitor as down this many days before the domain expires. 0 disables it.",
+			},
+
+			"detect_registrar_change": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Alert when the registrar of the domain changes.",
+			},
+
+			"registrar": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expected registrar of the domain. If empty, changes are detected against the last check.",
+			},
+
+			"detect_name_server_change": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Alert when the name servers of the domain change.",
+			},
+
+			"change_severity": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      api.Trouble.String(),
+				ValidateFunc: validateStringInSlice(api.Down.String(), api.Trouble.String()),
+				Description:  "Status reported when a registrar or name server change is detected.",
+			},
+		}),
+	}
+}
+
+func domainExpiryMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	return domainExpiryMonitorCreateOrUpdate(d, meta)
+}
+
+func domainExpiryMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	return domainExpiryMonitorCreateOrUpdate(d, meta)
+}
+
+func domainExpiryMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m := domainExpiryMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "DOMAIN_EXPIRY", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateDomainExpiryMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating domain expiry monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		if err := client.UpdateDomainExpiryMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating domain expiry monitor %s: %w", d.Id(), err)
+		}
+	}
+
+	return readAfterWrite(d, "domain expiry monitor", timeout, func() error {
+		m, err := client.GetDomainExpiryMonitor(d.Id())
+		if err != nil {
+			return err
+		}
+		updateDomainExpiryMonitorResourceData(d, m)
+		return nil
+	})
+}
+
+// domainExpiryMonitorFromResourceData builds the API representation of the monitor configured in d.
+func domainExpiryMonitorFromResourceData(d *schema.ResourceData) *api.DomainExpiryMonitor {
+	// the severity was validated
+	changeSeverity, _ := api.ParseStatus(d.Get("change_severity").(string))
+
+	return &api.DomainExpiryMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "DOMAIN_EXPIRY",
+		DomainName:            d.Get("domain").(string),
+		WhoisServer:           clearableString(d, "whois_server"),
+		Port:                  d.Get("whois_port").(int),
+		Timeout:               d.Get("timeout").(int),
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		ExpireDays:            d.Get("expiry_alert_days").(int),
+		ExpireDaysDown:        d.Get("expiry_down_days").(int),
+		RegistrarChangeCheck:  d.Get("detect_registrar_change").(bool),
+		Registrar:             clearableString(d, "registrar"),
+		NameServerChangeCheck: d.Get("detect_name_server_change").(bool),
+		ChangeSeverity:        changeSeverity,
+		LocationProfileID:     d.Get("location_profile_id").(string),
+		NotificationProfileID: d.Get("notification_profile_id").(string),
+		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
+	}
+}
+
+// validateDomainExpiryDays checks that the monitor is reported as trouble before it is reported as down.
+func validateDomainExpiryDays(d *schema.ResourceDiff, meta interface{}) error {
+	alertDays, downDays := d.Get("expiry_alert_days").(int), d.Get("expiry_down_days").(int)
+	if downDays > alertDays {
+		return fmt.Errorf("expiry_down_days (%d) must not exceed expiry_alert_days (%d)", downDays, alertDays)
+	}
+	return nil
+}
+
+func domainExpiryMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m, err := client.GetDomainExpiryMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "domain expiry monitor", err)
+	}
+	updateDomainExpiryMonitorResourceData(d, m)
+
+	return nil
+}
+
+func updateDomainExpiryMonitorResourceData(d *schema.ResourceData, m *api.DomainExpiryMonitor) {
+	d.Set("display_name", m.DisplayName)
+	d.Set("domain", m.DomainName)
+	d.Set("whois_server", unfixEmpty(m.WhoisServer))
+	d.Set("whois_port", m.Port)
+	d.Set("timeout", m.Timeout)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
+	d.Set("expiry_alert_days", m.ExpireDays)
+	d.Set("expiry_down_days", m.ExpireDaysDown)
+	d.Set("detect_registrar_change", m.RegistrarChangeCheck)
+	d.Set("registrar", unfixEmpty(m.Registrar))
+	d.Set("detect_name_server_change", m.NameServerChangeCheck)
+	d.Set("change_severity", m.ChangeSeverity.String())
+	d.Set("location_profile_id", m.LocationProfileID)
+	d.Set("notification_profile_id", m.NotificationProfileID)
+	d.Set("threshold_profile_id", m.ThresholdProfileID)
+	d.Set("monitor_groups", m.MonitorGroups)
+	d.Set("user_group_ids", m.UserGroupIDs)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
+}
+
+func domainExpiryMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	return handleDeleteError(d, "domain expiry monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
+}
@@ -0,0 +1,165 @@
+package site24x7
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestDomainExpiryMonitor(t *testing.T) {
+	const config1 = `
+		resource "site24x7_domain_expiry_monitor" "test" {
+			display_name = "sourcegraph.com registration"
+			domain = "sourcegraph.com"
+		}
+	`
+
+	const config2 = `
+		resource "site24x7_domain_expiry_monitor" "test" {
+			display_name = "sourcegraph.com registration"
+			domain = "sourcegraph.com"
+			whois_server = "whois.verisign-grs.com"
+			whois_port = 4343
+			expiry_alert_days = 60
+			expiry_down_days = 7
+			detect_registrar_change = true
+			registrar = "MarkMonitor Inc."
+			detect_name_server_change = true
+			change_severity = "down"
+		}
+	`
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_domain_expiry_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_domain_expiry_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "check_frequency", "1440"),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "whois_port", "43"),
+					resource.TestCheckResourceAttrSet("site24x7_domain_expiry_monitor.test", "threshold_profile_id"),
+					checkMonitorAPI("site24x7_domain_expiry_monitor.test", map[string]interface{}{
+						"whois_server": nil,
+						"registrar":    nil,
+					}),
+				),
+			},
+
+			resource.TestStep{
+				Config: config2,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_domain_expiry_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "whois_server", "whois.verisign-grs.com"),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "expiry_down_days", "7"),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "change_severity", "down"),
+					checkMonitorAPI("site24x7_domain_expiry_monitor.test", map[string]interface{}{
+						"whois_server": "whois.verisign-grs.com",
+						"registrar":    "MarkMonitor Inc.",
+					}),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_domain_expiry_monitor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_domain_expiry_monitor.test",
+				ImportState:       true,
+				ImportStateId:     "sourcegraph.com registration",
+				ImportStateVerify: true,
+			},
+
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "whois_server", ""),
+					resource.TestCheckResourceAttr("site24x7_domain_expiry_monitor.test", "registrar", ""),
+					// the API keeps omitted fields, so removed ones are cleared with a placeholder
+					checkMonitorAPI("site24x7_domain_expiry_monitor.test", map[string]interface{}{
+						"whois_server": " ",
+						"registrar":    " ",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestDomainExpiryMonitorAdopt checks that a domain monitor created outside of Terraform can be imported by name.
+func TestDomainExpiryMonitorAdopt(t *testing.T) {
+	if testFakeServer == nil {
+		t.Skip("needs the fake API")
+	}
+	id := testFakeServer.Add("monitors", map[string]interface{}{
+		"display_name":        "example.com registration",
+		"type":                "DOMAIN_EXPIRY",
+		"domain_name":         "example.com",
+		"port":                43,
+		"timeout":             10,
+		"check_frequency":     "1440",
+		"expire_days":         45,
+		"change_severity":     2,
+		"user_group_ids":      []interface{}{"1"},
+		"registrar":           "Example Registrar",
+		"location_profile_id": "1",
+	})
+	defer testFakeServer.Remove("monitors", id)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: `
+					resource "site24x7_domain_expiry_monitor" "adopted" {
+						display_name = "example.com registration"
+						domain = "example.com"
+					}
+				`,
+				ResourceName:  "site24x7_domain_expiry_monitor.adopted",
+				ImportState:   true,
+				ImportStateId: "example.com registration",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("got %d imported states, want 1", len(states))
+					}
+					attrs := states[0].Attributes
+					want := map[string]string{
+						"domain":            "example.com",
+						"whois_server":      "",
+						"expiry_alert_days": "45",
+						"registrar":         "Example Registrar",
+						"change_severity":   "trouble",
+					}
+					for k, v := range want {
+						if attrs[k] != v {
+							return fmt.Errorf("got %s %q, want %q", k, attrs[k], v)
+						}
+					}
+					if states[0].ID != id {
+						return fmt.Errorf("imported monitor %s, want %s", states[0].ID, id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestDomainExpiryMonitorValidation(t *testing.T) {
+	const domain = `domain = "sourcegraph.com"`
+	testValidation(t, "site24x7_domain_expiry_monitor", map[string]validationCase{
+		"domain":           {`domain = "https://sourcegraph.com"`, `domain must be`},
+		"whois_server":     {domain + "\n whois_server = \"whois://x\"", `whois_server must be`},
+		"expiry_down_days": {domain + "\n expiry_alert_days = 10\n expiry_down_days = 20", `expiry_down_days \(20\) must not exceed`},
+		"change_severity":  {domain + "\n change_severity = \"up\"", `change_severity must be`},
+	})
+}
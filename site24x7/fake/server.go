@@ -0,0 +1,337 @@
+// Package fake implements an in-memory fake of the Site24x7 REST API and the Zoho OAuth token endpoint, for
+// testing code that uses the API without a Site24x7 account.
+//
+//	srv := fake.NewServer()
+//	defer srv.Close()
+//
+//	ator, _ := oauth.NewAuthenticator(fake.ClientID, fake.ClientSecret, fake.RefreshToken,
+//		oauth.Options{AccountsURL: srv.AccountsURL()})
+//	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL(), Authenticator: ator})
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted by the fake token endpoint.
+const (
+	ClientID     = "fake-client-id"
+	ClientSecret = "fake-client-secret"
+	RefreshToken = "fake-refresh-token"
+	GenerateCode = "fake-generate-code"
+)
+
+// Server is a fake Site24x7 API server. It is safe for concurrent use.
+type Server struct {
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	nextID      int64
+	tokens      map[string]time.Time // access tokens by expiration
+	collections map[string]*collection
+
+	// ExpiresInSec is the lifetime of issued access tokens. Requests with expired tokens are rejected.
+	ExpiresInSec int
+
+	staleReads int
+	stale      map[string]int
+}
+
+// collection holds the objects of one API endpoint, keyed by id. Objects are stored as the decoded JSON the
+// client sent, so any monitor or profile type is accepted.
+type collection struct {
+	idField string
+	objects map[string]map[string]interface{}
+	order   []string
+}
+
+// NewServer starts a fake server seeded with a user, a default location profile, notification profile, threshold
+// profile and user group, like a new Site24x7 account.
+func NewServer() *Server {
+	s := &Server{
+		nextID:       113770000000001000,
+		tokens:       make(map[string]time.Time),
+		stale:        make(map[string]int),
+		ExpiresInSec: 3600,
+		collections: map[string]*collection{
+			"monitors":              newCollection("monitor_id"),
+			"location_profiles":     newCollection("profile_id"),
+			"notification_profiles": newCollection("profile_id"),
+			"threshold_profiles":    newCollection("profile_id"),
+			"user_groups":           newCollection("user_group_id"),
+			"users":                 newCollection("user_id"),
+		},
+	}
+
+	s.Add("users", map[string]interface{}{"display_name": "Owner", "email_address": "owner@example.com"})
+	s.Add("location_profiles", map[string]interface{}{"profile_name": "Default Location Profile", "primary_location": "1"})
+	s.Add("notification_profiles", map[string]interface{}{"profile_name": "Default Notification"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - Website", "type": "URL"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - REST API", "type": "RESTAPI"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - REST API Transaction", "type": "RESTAPISEQ"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - SSL Certificate", "type": "SSL_CERT"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - Domain Expiry", "type": "DOMAIN_EXPIRY"})
+	s.Add("threshold_profiles", map[string]interface{}{"profile_name": "Default Threshold - DNS Server", "type": "DNS"})
+	s.Add("user_groups", map[string]interface{}{"display_name": "Admin Group", "users": []interface{}{}})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/token", s.handleToken)
+	mux.HandleFunc("/api/location_template", s.handleLocationTemplate)
+	mux.HandleFunc("/api/", s.handleAPI)
+	s.srv = httptest.NewServer(mux)
+	return s
+}
+
+func newCollection(idField string) *collection {
+	return &collection{
+		idField: idField,
+		objects: make(map[string]map[string]interface{}),
+	}
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// AccountsURL returns the URL to use as the Zoho accounts server.
+func (s *Server) AccountsURL() string {
+	return s.srv.URL
+}
+
+// APIBaseURL returns the URL to use as the Site24x7 API base URL.
+func (s *Server) APIBaseURL() string {
+	return s.srv.URL + "/api"
+}
+
+// Add stores obj in the named collection (e.g. "monitors" or "user_groups") under a new id and returns the id.
+func (s *Server) Add(collection string, obj map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(s.collections[collection], obj)
+}
+
+// Get returns the object with the given id from the named collection.
+func (s *Server) Get(collection, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collections[collection].objects[id]
+	return obj, ok
+}
+
+// Remove deletes the object with the given id from the named collection, e.g. to simulate a change made outside
+// of the client under test.
+func (s *Server) Remove(collection, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[collection].remove(id)
+}
+
+// SetStaleReads sets the number of times objects created through the API afterwards are reported as not found
+// when read, to simulate the eventual consistency of the real API.
+func (s *Server) SetStaleReads(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.staleReads = n
+}
+
+// ExpireTokens expires all access tokens issued so far, e.g. to simulate a token expiring or being revoked while
+// the client under test holds it.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.tokens {
+		s.tokens[token] = time.Time{}
+	}
+}
+
+// Clear deletes all objects of the named collection, e.g. to simulate an account without any location profiles.
+func (s *Server) Clear(collection string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collections[collection] = newCollection(s.collections[collection].idField)
+}
+
+func (s *Server) add(c *collection, obj map[string]interface{}) string {
+	s.nextID++
+	id := strconv.FormatInt(s.nextID, 10)
+	obj[c.idField] = id
+	c.objects[id] = obj
+	c.order = append(c.order, id)
+	return id
+}
+
+func (c *collection) remove(id string) {
+	delete(c.objects, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if r.Method != http.MethodPost || q.Get("client_id") != ClientID || q.Get("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"error": "invalid_client"})
+		return
+	}
+
+	resp := map[string]interface{}{
+		"expires_in_sec": s.ExpiresInSec,
+		"token_type":     "Bearer",
+	}
+	switch {
+	case q.Get("grant_type") == "refresh_token" && q.Get("refresh_token") == RefreshToken:
+	case q.Get("grant_type") == "authorization_code" && q.Get("code") == GenerateCode:
+		resp["refresh_token"] = RefreshToken
+	default:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"error": "invalid_code"})
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	token := fmt.Sprintf("1000.fake.%d", s.nextID)
+	s.tokens[token] = time.Now().Add(time.Duration(s.ExpiresInSec) * time.Second)
+	s.mu.Unlock()
+
+	resp["access_token"] = token
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// locations are the monitoring locations listed by the location template.
+var locations = []interface{}{
+	map[string]interface{}{"location_id": "1", "display_name": "Dallas - US"},
+	map[string]interface{}{"location_id": "2", "display_name": "London - UK"},
+	map[string]interface{}{"location_id": "3", "display_name": "Mumbai - IN"},
+}
+
+func (s *Server) handleLocationTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authorized(w, r) {
+		return
+	}
+	writeData(w, http.StatusOK, map[string]interface{}{"locations": locations})
+}
+
+// authorized checks the access token of r, writing an error response if it is invalid. s.mu must be held.
+func (s *Server) authorized(w http.ResponseWriter, r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Zoho-oauthtoken ")
+	if expires, ok := s.tokens[token]; !ok || !time.Now().Before(expires) {
+		writeError(w, http.StatusUnauthorized, 1031, "Oauth token is invalid or expired.")
+		return false
+	}
+	return true
+}
+
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.authorized(w, r) {
+		return
+	}
+
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/"), "/")
+	c, ok := s.collections[parts[0]]
+	if !ok || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, 404, "Resource not found.")
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			list := make([]interface{}, 0, len(c.order))
+			for _, id := range c.order {
+				list = append(list, c.objects[id])
+			}
+			writeData(w, http.StatusOK, list)
+		case http.MethodPost:
+			obj, ok := decodeObject(w, r)
+			if !ok {
+				return
+			}
+			id := s.add(c, obj)
+			if s.staleReads > 0 {
+				s.stale[id] = s.staleReads
+			}
+			writeData(w, http.StatusCreated, obj)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 405, "Method not allowed.")
+		}
+		return
+	}
+
+	id := parts[1]
+	obj, ok := c.objects[id]
+	if ok && r.Method == http.MethodGet && s.stale[id] > 0 {
+		s.stale[id]--
+		ok = false
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, 1001, "Invalid ID.")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeData(w, http.StatusOK, obj)
+	case http.MethodPut:
+		update, ok := decodeObject(w, r)
+		if !ok {
+			return
+		}
+		// like the real API, fields missing from the update keep their values
+		for k, v := range update {
+			obj[k] = v
+		}
+		obj[c.idField] = id
+		writeData(w, http.StatusOK, obj)
+	case http.MethodDelete:
+		c.remove(id)
+		writeData(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 405, "Method not allowed.")
+	}
+}
+
+func decodeObject(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var obj map[string]interface{}
+	if err := json.NewDecoder(r.Body).Decode(&obj); err != nil || obj == nil {
+		writeError(w, http.StatusBadRequest, 1003, "Invalid JSON input.")
+		return nil, false
+	}
+	return obj, true
+}
+
+func writeData(w http.ResponseWriter, status int, data interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":    0,
+		"message": "success",
+		"data":    data,
+	})
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error_code": code,
+		"message":    message,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,104 @@
+package fake
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
+)
+
+func TestServer(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ator, err := oauth.NewAuthenticator(ClientID, ClientSecret, RefreshToken, oauth.Options{AccountsURL: srv.AccountsURL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL(), Authenticator: ator})
+
+	groups, err := client.ListUserGroups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("got %d user groups, want 1", len(groups))
+	}
+
+	id, err := client.CreateWebsiteMonitor(&api.WebsiteMonitor{DisplayName: "test", Type: "URL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateWebsiteMonitor(id, &api.WebsiteMonitor{DisplayName: "renamed", Type: "URL"}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := client.GetWebsiteMonitor(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MonitorID != id || m.DisplayName != "renamed" {
+		t.Errorf("got monitor %+v", m)
+	}
+
+	if err := client.DeleteMonitor(id); err != nil {
+		t.Fatal(err)
+	}
+	if exists, err := client.MonitorExists(id); err != nil || exists {
+		t.Errorf("got exists %v, %v after delete", exists, err)
+	}
+}
+
+// TestServerUpdate checks that updates keep the fields they omit, like the real API.
+func TestServerUpdate(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ator, err := oauth.NewAuthenticator(ClientID, ClientSecret, RefreshToken, oauth.Options{AccountsURL: srv.AccountsURL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL(), Authenticator: ator})
+
+	authPass := "secret"
+	id, err := client.CreateWebsiteMonitor(&api.WebsiteMonitor{DisplayName: "test", Type: "URL", AuthPass: &authPass})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.UpdateWebsiteMonitor(id, &api.WebsiteMonitor{DisplayName: "renamed", Type: "URL"}); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := srv.Get("monitors", id)
+	if m["display_name"] != "renamed" || m["auth_pass"] != "secret" {
+		t.Errorf("got monitor %v after update, want renamed with auth_pass kept", m)
+	}
+}
+
+func TestServerExpireTokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	ator, err := oauth.NewAuthenticator(ClientID, ClientSecret, RefreshToken, oauth.Options{AccountsURL: srv.AccountsURL()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := ator.AccessToken()
+	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL(), Authenticator: ator})
+
+	srv.ExpireTokens()
+	if _, err := client.ListMonitors(); err != nil {
+		t.Fatalf("got error %v with expired token, want it renewed", err)
+	}
+	if ator.AccessToken() == token {
+		t.Error("access token was not renewed")
+	}
+}
+
+func TestServerRejectsUnknownToken(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	client := api.NewClient(api.Config{BaseURL: srv.APIBaseURL()})
+	if _, err := client.ListMonitors(); err == nil {
+		t.Fatal("got no error for unauthorized request")
+	}
+}
@@ -0,0 +1,64 @@
+package site24x7
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// importMonitor returns an import function for monitors of the given API type. Monitors can be imported by id if the
+// monitor is of that type, or by display name if the name matches exactly one monitor of that type:
+//
+//	terraform import site24x7_website_monitor.example 113770000023231001
+//	terraform import site24x7_website_monitor.example "Example homepage"
+func importMonitor(monitorType string) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		client := meta.(*providerMeta).client
+
+		if isMonitorID(d.Id()) {
+			m, err := client.GetMonitor(d.Id())
+			if err != nil {
+				return nil, fmt.Errorf("error reading monitor %s: %w", d.Id(), err)
+			}
+			if m.Type != monitorType {
+				return nil, fmt.Errorf("monitor %s is a %s monitor, not a %s monitor", d.Id(), m.Type, monitorType)
+			}
+			return []*schema.ResourceData{d}, nil
+		}
+
+		monitors, err := client.ListMonitors()
+		if err != nil {
+			return nil, fmt.Errorf("error listing monitors: %w", err)
+		}
+
+		var ids []string
+		for _, m := range monitors {
+			if m.Type == monitorType && m.DisplayName == d.Id() {
+				ids = append(ids, m.MonitorID)
+			}
+		}
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("no %s monitor named %q found", monitorType, d.Id())
+		case 1:
+			d.SetId(ids[0])
+			return []*schema.ResourceData{d}, nil
+		default:
+			return nil, fmt.Errorf("%d %s monitors named %q found (%s), import by id instead", len(ids), monitorType, d.Id(), strings.Join(ids, ", "))
+		}
+	}
+}
+
+// isMonitorID reports whether s looks like a monitor id, which is a string of digits.
+func isMonitorID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
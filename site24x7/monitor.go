@@ -0,0 +1,148 @@
+package site24x7
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// monitorSchema returns the schema of a monitor resource: the given type-specific attributes and the attributes
+// shared by all monitor types, i.e. the display name, check frequency, profiles, groups and actions.
+func monitorSchema(attrs map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"display_name": &schema.Schema{
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validateNotEmpty,
+		},
+
+		"check_frequency": &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validateIntInSlice(checkFrequencies...),
+		},
+
+		"location_profile_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+
+		"notification_profile_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"threshold_profile_id": &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+
+		"monitor_groups": &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Optional: true,
+		},
+
+		"user_group_ids": &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Computed: true,
+		},
+
+		"action": &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": &schema.Schema{
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"alert_type": &schema.Schema{
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateStringInSlice(api.StatusNames()...),
+					},
+				},
+			},
+			Optional: true,
+		},
+	}
+	for k, v := range attrs {
+		s[k] = v
+	}
+	return s
+}
+
+// stringList converts the value of a TypeList or TypeSet of strings to a slice. The slice is empty rather than nil
+// if there are no strings, so that it clears the field when sent to the API, which keeps omitted fields on update.
+func stringList(v interface{}) []string {
+	if set, ok := v.(*schema.Set); ok {
+		v = set.List()
+	}
+	l := []string{}
+	for _, s := range v.([]interface{}) {
+		l = append(l, s.(string))
+	}
+	return l
+}
+
+// actionRefsFromResourceData returns the action blocks of d, as an empty slice if there are none like stringList.
+func actionRefsFromResourceData(d *schema.ResourceData) []api.ActionRef {
+	actionRefs := []api.ActionRef{}
+	for _, a := range d.Get("action").([]interface{}) {
+		action := a.(map[string]interface{})
+		// the alert type was validated
+		alertType, _ := api.ParseStatus(action["alert_type"].(string))
+		actionRefs = append(actionRefs, api.ActionRef{ActionID: action["id"].(string), AlertType: alertType})
+	}
+	return actionRefs
+}
+
+// flattenActionRefs returns the action blocks representing actionRefs.
+func flattenActionRefs(actionRefs []api.ActionRef) []map[string]interface{} {
+	actions := make([]map[string]interface{}, len(actionRefs))
+	for i, r := range actionRefs {
+		actions[i] = map[string]interface{}{
+			"id":         r.ActionID,
+			"alert_type": r.AlertType.String(),
+		}
+	}
+	return actions
+}
+
+// clearableString returns the value of the string attribute key to send to the API in a field that is omitted if
+// empty. If the attribute was set before, the fixEmpty placeholder is returned instead to clear the field, as the API
+// keeps the fields omitted from an update.
+func clearableString(d *schema.ResourceData, key string) string {
+	old, new := d.GetChange(key)
+	if new.(string) == "" && old.(string) != "" {
+		return fixEmpty("")
+	}
+	return new.(string)
+}
+
+// customHeaders returns the headers of a custom_headers map.
+func customHeaders(v interface{}) []api.Header {
+	customHeaders := []api.Header{}
+	for k, v := range v.(map[string]interface{}) {
+		customHeaders = append(customHeaders, api.Header{Name: k, Value: v.(string)})
+	}
+	return customHeaders
+}
+
+// flattenCustomHeaders returns the custom_headers map representing headers.
+func flattenCustomHeaders(headers []api.Header) map[string]interface{} {
+	customHeaders := make(map[string]interface{})
+	for _, h := range headers {
+		if h.Name == "" {
+			continue
+		}
+		customHeaders[h.Name] = h.Value
+	}
+	return customHeaders
+}
@@ -0,0 +1,44 @@
+package site24x7
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// The Site24x7 API canonicalizes some values it stores, so reading them back can yield a different representation
+// of the configured value. The functions below map between the two so that plans converge.
+
+// fixEmpty returns the placeholder the API needs to clear a string field, as it ignores empty strings.
+func fixEmpty(s string) string {
+	if s == "" {
+		return " "
+	}
+	return s
+}
+
+// unfixEmpty is the inverse of fixEmpty for values read from the API.
+func unfixEmpty(s string) string {
+	if s == " " {
+		return ""
+	}
+	return s
+}
+
+// suppressEquivalentURL suppresses diffs between URLs that only differ by a trailing slash, which the API adds or
+// strips.
+func suppressEquivalentURL(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSuffix(old, "/") == strings.TrimSuffix(new, "/")
+}
+
+// suppressEquivalentJSON suppresses diffs between JSON documents that only differ by formatting, which the API
+// doesn't preserve.
+func suppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	var o, n bytes.Buffer
+	if json.Compact(&o, []byte(old)) != nil || json.Compact(&n, []byte(new)) != nil {
+		return false
+	}
+	return o.String() == n.String()
+}
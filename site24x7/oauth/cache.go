@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// tokenCache persists access tokens in a directory, so that concurrent and subsequent processes using the same
+// credentials share them instead of each requesting a new one; Zoho limits how often a refresh token may be used.
+//
+// Only the access token and its lifetime are stored. Files are named after a hash of the accounts server, client id
+// and refresh token, so different accounts, data centers or credentials never share a token.
+type tokenCache struct {
+	path string
+}
+
+func newTokenCache(dir, accountsURL, clientId, refreshToken string) *tokenCache {
+	h := sha256.New()
+	for _, s := range []string{accountsURL, clientId, refreshToken} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return &tokenCache{
+		path: filepath.Join(dir, "site24x7-token-"+hex.EncodeToString(h.Sum(nil)[:16])+".json"),
+	}
+}
+
+// lock acquires an exclusive lock on the cache entry, waiting for other processes holding it, and returns a function
+// releasing it.
+func (c *tokenCache) lock() (unlock func(), err error) {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return nil, err
+	}
+	return lockFile(c.path + ".lock")
+}
+
+// load returns the cached token. The error satisfies os.IsNotExist if there is none.
+func (c *tokenCache) load() (*tokens, error) {
+	b, err := ioutil.ReadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	var tkns tokens
+	if err := json.Unmarshal(b, &tkns); err != nil {
+		return nil, err
+	}
+	return &tkns, nil
+}
+
+// store replaces the cached token with the access token of tkns.
+func (c *tokenCache) store(tkns *tokens) error {
+	b, err := json.Marshal(&tokens{
+		AccessToken:         tkns.AccessToken,
+		TokenGenerationTime: tkns.TokenGenerationTime,
+		ExpiresInSec:        tkns.ExpiresInSec,
+	})
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first so that readers never see a partially written token; TempFile creates
+	// files readable by the owner only
+	f, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), c.path)
+}
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
 )
@@ -18,6 +19,8 @@ var (
 	clientId     = flag.String("clientId", "", "(required) client id")
 	clientSecret = flag.String("clientSecret", "", "(required) client secret")
 	generateCode = flag.String("generateCode", "", "(required) generate code token")
+	dataCenter   = flag.String("dataCenter", oauth.DefaultDataCenter, "data center of the Site24x7 account ("+strings.Join(oauth.DataCenterNames(), ", ")+")")
+	accountsURL  = flag.String("accountsURL", "", "Zoho accounts server, overrides the one of -dataCenter")
 )
 
 func main() {
@@ -29,7 +32,17 @@ func main() {
 		os.Exit(2)
 	}
 
-	refreshToken, err := oauth.GenerateRefreshToken(*clientId, *clientSecret, *generateCode)
+	dc, err := oauth.LookupDataCenter(*dataCenter)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	opts := oauth.Options{AccountsURL: dc.AccountsURL}
+	if *accountsURL != "" {
+		opts.AccountsURL = *accountsURL
+	}
+
+	refreshToken, err := oauth.GenerateRefreshToken(*clientId, *clientSecret, *generateCode, opts)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -44,4 +57,10 @@ func main() {
 	fmt.Println("oauth_client_id = " + oad.ClientId)
 	fmt.Println("oauth_client_secret = " + oad.ClientSecret)
 	fmt.Println("oauth_refresh_token = " + oad.RefreshToken)
+	if !strings.EqualFold(*dataCenter, oauth.DefaultDataCenter) {
+		fmt.Println("data_center = " + strings.ToUpper(*dataCenter))
+	}
+	if *accountsURL != "" {
+		fmt.Println("accounts_url = " + *accountsURL)
+	}
 }
@@ -0,0 +1,87 @@
+package oauth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Credentials authenticate with the Site24x7 API, either with a refresh token that Authenticators exchange for
+// access tokens, or with an access token obtained elsewhere that is used as is.
+type Credentials struct {
+	ClientId     string
+	ClientSecret string
+	RefreshToken string
+	AccessToken  string
+}
+
+// ReadCredentialsFile reads credentials from a JSON file with the keys CLIENT_ID, CLIENT_SECRET and REFRESH_TOKEN,
+// or ACCESS_TOKEN.
+func ReadCredentialsFile(path string) (*Credentials, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+	creds, err := parseCredentials(b)
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file %s: %w", path, err)
+	}
+	return creds, nil
+}
+
+// RunCredentialProcess runs command with the shell and reads credentials from its output, which must be JSON like
+// the content of a credentials file (see ReadCredentialsFile).
+func RunCredentialProcess(command string) (*Credentials, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("error running credential process: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("error running credential process: %w", err)
+	}
+	creds, err := parseCredentials(out)
+	if err != nil {
+		return nil, fmt.Errorf("error reading output of credential process: %w", err)
+	}
+	return creds, nil
+}
+
+func parseCredentials(b []byte) (*Credentials, error) {
+	var tkns tokens
+	if err := json.Unmarshal(b, &tkns); err != nil {
+		return nil, err
+	}
+	creds := &Credentials{
+		ClientId:     tkns.ClientId,
+		ClientSecret: tkns.ClientSecret,
+		RefreshToken: tkns.RefreshToken,
+		AccessToken:  tkns.AccessToken,
+	}
+	if creds.AccessToken == "" && (creds.ClientId == "" || creds.ClientSecret == "" || creds.RefreshToken == "") {
+		return nil, errors.New("expected either ACCESS_TOKEN or CLIENT_ID, CLIENT_SECRET and REFRESH_TOKEN")
+	}
+	return creds, nil
+}
+
+// NewAuthenticator returns an authenticator using the credentials: a static one if they carry an access token,
+// otherwise one exchanging the refresh token for access tokens.
+func (creds *Credentials) NewAuthenticator(opts Options) (*Authenticator, error) {
+	if creds.AccessToken != "" {
+		return NewStaticAuthenticator(creds.AccessToken), nil
+	}
+	return NewAuthenticator(creds.ClientId, creds.ClientSecret, creds.RefreshToken, opts)
+}
@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestReadCredentialsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "site24x7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "credentials.json")
+	if err := ioutil.WriteFile(path, []byte(`{"CLIENT_ID": "id", "CLIENT_SECRET": "secret", "REFRESH_TOKEN": "refresh"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := ReadCredentialsFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Credentials{ClientId: "id", ClientSecret: "secret", RefreshToken: "refresh"}); *creds != want {
+		t.Errorf("got credentials %+v, want %+v", *creds, want)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"CLIENT_ID": "id"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadCredentialsFile(path); err == nil {
+		t.Error("got no error for incomplete credentials")
+	}
+}
+
+func TestRunCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	creds, err := RunCredentialProcess(`echo '{"ACCESS_TOKEN": "token"}'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds.AccessToken != "token" {
+		t.Errorf("got access token %q, want token", creds.AccessToken)
+	}
+
+	if _, err := RunCredentialProcess("echo denied >&2; exit 1"); err == nil || err.Error() != "error running credential process: exit status 1: denied" {
+		t.Errorf("got error %v", err)
+	}
+}
+
+func TestStaticAuthenticator(t *testing.T) {
+	ator := NewStaticAuthenticator("token")
+	if tok, err := ator.Token(); err != nil || tok != "token" {
+		t.Fatalf("got token %q, error %v", tok, err)
+	}
+	if _, err := ator.Renew("token"); err != errStaticToken {
+		t.Errorf("got error %v renewing static token, want %v", err, errStaticToken)
+	}
+}
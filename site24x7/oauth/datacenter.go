@@ -0,0 +1,50 @@
+package oauth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DataCenter describes the endpoints of one of the regional Site24x7 data centers.
+type DataCenter struct {
+	// AccountsURL is the Zoho accounts server issuing OAuth tokens.
+	AccountsURL string
+	// APIBaseURL is the root of the Site24x7 REST API.
+	APIBaseURL string
+}
+
+// DefaultDataCenter is the data center used when none is specified.
+const DefaultDataCenter = "US"
+
+// DataCenters maps data center names to their endpoints.
+// See https://www.site24x7.com/help/api/#data-centers.
+var DataCenters = map[string]DataCenter{
+	"US": {AccountsURL: "https://accounts.zoho.com", APIBaseURL: "https://www.site24x7.com/api"},
+	"EU": {AccountsURL: "https://accounts.zoho.eu", APIBaseURL: "https://www.site24x7.eu/api"},
+	"IN": {AccountsURL: "https://accounts.zoho.in", APIBaseURL: "https://www.site24x7.in/api"},
+	"AU": {AccountsURL: "https://accounts.zoho.com.au", APIBaseURL: "https://www.site24x7.net.au/api"},
+	"CN": {AccountsURL: "https://accounts.zoho.com.cn", APIBaseURL: "https://www.site24x7.cn/api"},
+	"JP": {AccountsURL: "https://accounts.zoho.jp", APIBaseURL: "https://www.site24x7.jp/api"},
+	"CA": {AccountsURL: "https://accounts.zohocloud.ca", APIBaseURL: "https://www.site24x7.ca/api"},
+	"UK": {AccountsURL: "https://accounts.zoho.uk", APIBaseURL: "https://www.site24x7.uk/api"},
+}
+
+// LookupDataCenter returns the endpoints of the data center with the given name (case insensitive).
+func LookupDataCenter(name string) (DataCenter, error) {
+	dc, ok := DataCenters[strings.ToUpper(name)]
+	if !ok {
+		return DataCenter{}, fmt.Errorf("unknown data center %q, expected one of %s", name, strings.Join(DataCenterNames(), ", "))
+	}
+	return dc, nil
+}
+
+// DataCenterNames returns the sorted names of all known data centers.
+func DataCenterNames() []string {
+	names := make([]string, 0, len(DataCenters))
+	for name := range DataCenters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
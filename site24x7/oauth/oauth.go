@@ -1,19 +1,25 @@
 package oauth
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	tokenDomain = "https://accounts.zoho.com"
-	tokenApi    = "/oauth/v2/token"
-	timeout     = time.Second
+	tokenApi = "/oauth/v2/token"
+	timeout  = 30 * time.Second
+
+	// refreshRetryInterval is how long to wait after a failed refresh before requesting a token again, as Zoho
+	// rate-limits the token endpoint.
+	refreshRetryInterval = 30 * time.Second
 )
 
 func setRequestHeaders(request *http.Request, accessToken string) {
@@ -22,29 +28,56 @@ func setRequestHeaders(request *http.Request, accessToken string) {
 	request.Header.Set("Authorization", "Zoho-oauthtoken "+accessToken)
 }
 
-func getURL(urlValues url.Values) string {
-	baseAccURL := fmt.Sprintf("%s%s", tokenDomain, tokenApi)
-	urlToReturn := fmt.Sprintf("%s?%s", baseAccURL, urlValues.Encode())
-	return urlToReturn
+// Options configures how an Authenticator talks to Zoho.
+type Options struct {
+	// AccountsURL is the Zoho accounts server to acquire tokens from. If empty, the accounts server of
+	// DefaultDataCenter is used.
+	AccountsURL string
+
+	// HTTPClient is used to request tokens. If nil, a client using http.DefaultTransport with a 30 second timeout
+	// is used.
+	HTTPClient *http.Client
+
+	// TokenCacheDir, if set, is a directory where access tokens are cached for use by later or concurrent
+	// authenticators with the same credentials, e.g. other Terraform runs. See tokenCache.
+	TokenCacheDir string
+}
+
+func (opts Options) accountsURL() string {
+	if opts.AccountsURL != "" {
+		return strings.TrimSuffix(opts.AccountsURL, "/")
+	}
+	return DataCenters[DefaultDataCenter].AccountsURL
 }
 
 type Authenticator struct {
-	tkns *tokens
+	mu    sync.Mutex
+	tkns  *tokens
+	opts  Options
+	cache *tokenCache
+
+	// static is set if the access token was obtained elsewhere and can't be refreshed.
+	static bool
+
+	// retryAt is when the token may be refreshed again after the refresh failed with refreshErr.
+	retryAt    time.Time
+	refreshErr error
+}
+
+// errStaticToken is returned when a static access token has to be refreshed.
+var errStaticToken = errors.New("the access token expired or was revoked and can't be refreshed without a refresh token")
+
+func (ator *Authenticator) getURL(urlValues url.Values) string {
+	baseAccURL := fmt.Sprintf("%s%s", ator.opts.accountsURL(), tokenApi)
+	urlToReturn := fmt.Sprintf("%s?%s", baseAccURL, urlValues.Encode())
+	return urlToReturn
 }
 
 func (ator *Authenticator) getClient() *http.Client {
-	trTLS11 := &http.Transport{
-		DisableKeepAlives: true,
-		TLSClientConfig: &tls.Config{
-			MaxVersion:         tls.VersionTLS13,
-			InsecureSkipVerify: true,
-		},
+	if ator.opts.HTTPClient != nil {
+		return ator.opts.HTTPClient
 	}
-	client := http.Client{
-		Transport: trTLS11,
-		Timeout:   timeout,
-	}
-	return &client
+	return &http.Client{Timeout: timeout}
 }
 
 func (ator *Authenticator) getAccessTokenFrom(urlToPost string) error {
@@ -72,57 +105,141 @@ func (ator *Authenticator) getAccessTokenFrom(urlToPost string) error {
 		return errors.New(respErr)
 	}
 
-	ator.tkns.AccessToken, _ = result["access_token"].(string)
-	ator.tkns.ExpiresInSec, _ = result["expires_in_sec"].(float64)
+	// keep the current token, which may still be usable, unless a new one was issued
+	accessToken, _ := result["access_token"].(string)
+	expiresInSec, _ := result["expires_in_sec"].(float64)
+	if accessToken == "" || expiresInSec == 0 {
+		return errors.New("error while fetching access token: empty token or no expiration")
+	}
+	ator.tkns.AccessToken = accessToken
+	ator.tkns.ExpiresInSec = expiresInSec
 
 	refreshToken, _ := result["refresh_token"].(string)
 	if refreshToken != "" {
 		ator.tkns.RefreshToken = refreshToken
 	}
 
-	if ator.tkns.AccessToken == "" || ator.tkns.ExpiresInSec == 0 {
-		return errors.New("error while fetching access token: empty token or no expiration")
-	}
 	ator.tkns.TokenGenerationTime = time.Now().UnixNano() / 1e6
 	return nil
 }
 
 func (ator *Authenticator) getAccessTokenFromRefreshToken() error {
-	return ator.getAccessTokenFrom(getURL(ator.tkns.refreshTokenURLValues()))
+	return ator.getAccessTokenFrom(ator.getURL(ator.tkns.refreshTokenURLValues()))
 }
 
 func (ator *Authenticator) setAccessTokenFromCode() error {
-	return ator.getAccessTokenFrom(getURL(ator.tkns.generatedCodeURLValues()))
+	return ator.getAccessTokenFrom(ator.getURL(ator.tkns.generatedCodeURLValues()))
 }
 
-func (ator *Authenticator) refresh() error {
-	err := ator.getAccessTokenFromRefreshToken()
+// refresh replaces the access token. If a cache is configured, a token cached by another authenticator is used
+// instead of requesting a new one, unless it has expired or is stale, the token rejected by the API.
+func (ator *Authenticator) refresh(stale string) error {
+	if ator.static {
+		return errStaticToken
+	}
+	if ator.cache == nil {
+		return ator.refreshFromZoho()
+	}
+
+	unlock, err := ator.cache.lock()
 	if err != nil {
-		// if we failed to refresh we want to try again in 30 secs
-		ator.tkns.ExpiresInSec = 30
+		log.Printf("[WARN] Not using the access token cache: %v", err)
+		return ator.refreshFromZoho()
+	}
+	defer unlock()
+
+	cached, err := ator.cache.load()
+	switch {
+	case err == nil && cached.AccessToken != "" && cached.AccessToken != stale && !cached.expired():
+		log.Printf("[DEBUG] Using cached access token")
+		ator.tkns.AccessToken = cached.AccessToken
+		ator.tkns.TokenGenerationTime = cached.TokenGenerationTime
+		ator.tkns.ExpiresInSec = cached.ExpiresInSec
+		return nil
+	case err != nil && !os.IsNotExist(err):
+		log.Printf("[WARN] Error reading the access token cache: %v", err)
+	}
+
+	if err := ator.refreshFromZoho(); err != nil {
+		return err
+	}
+	if err := ator.cache.store(ator.tkns); err != nil {
+		log.Printf("[WARN] Error writing the access token cache: %v", err)
+	}
+	return nil
+}
+
+// refreshFromZoho requests a new access token. Within refreshRetryInterval of a failed attempt it fails with the
+// same error without requesting one.
+func (ator *Authenticator) refreshFromZoho() error {
+	if time.Now().Before(ator.retryAt) {
+		return ator.refreshErr
+	}
+	if err := ator.getAccessTokenFromRefreshToken(); err != nil {
+		ator.retryAt = time.Now().Add(refreshRetryInterval)
+		ator.refreshErr = err
 		return err
 	}
+	ator.retryAt, ator.refreshErr = time.Time{}, nil
 	return nil
 }
 
 // AccessToken returns the access token.
 func (ator *Authenticator) AccessToken() string {
+	ator.mu.Lock()
+	defer ator.mu.Unlock()
 	return ator.tkns.AccessToken
 }
 
+// Token returns a valid access token, refreshing it first if it has expired.
+func (ator *Authenticator) Token() (string, error) {
+	ator.mu.Lock()
+	defer ator.mu.Unlock()
+
+	if !ator.static && ator.tkns.expired() {
+		if err := ator.refresh(""); err != nil {
+			// the token is considered expired expiryDelta early, so it may still be usable
+			if ator.tkns.AccessToken == "" || ator.tkns.expiresWithin(0) {
+				return "", err
+			}
+			log.Printf("[DEBUG] Using the current access token until it expires: %v", err)
+		}
+	}
+	return ator.tkns.AccessToken, nil
+}
+
+// Renew discards the access token stale, which the API rejected, and returns a fresh one.
+// If another caller already replaced stale the current token is returned without refreshing again.
+func (ator *Authenticator) Renew(stale string) (string, error) {
+	ator.mu.Lock()
+	defer ator.mu.Unlock()
+
+	if ator.tkns.AccessToken != stale && !ator.tkns.expired() {
+		return ator.tkns.AccessToken, nil
+	}
+	if err := ator.refresh(stale); err != nil {
+		return "", err
+	}
+	return ator.tkns.AccessToken, nil
+}
+
 // NewAuthenticator creates an authenticator that will acquire an access token from Zoho using the specified
 // client id, client secret and refresh token.
 // If an error occurred while obtaining the access token an error is returned.
-func NewAuthenticator(clientId, clientSecret, refreshToken string) (*Authenticator, error) {
+func NewAuthenticator(clientId, clientSecret, refreshToken string, opts Options) (*Authenticator, error) {
 	ator := &Authenticator{
 		tkns: &tokens{
 			ClientId:     clientId,
 			ClientSecret: clientSecret,
 			RefreshToken: refreshToken,
 		},
+		opts: opts,
+	}
+	if opts.TokenCacheDir != "" {
+		ator.cache = newTokenCache(opts.TokenCacheDir, opts.accountsURL(), clientId, refreshToken)
 	}
 
-	err := ator.refresh()
+	err := ator.refresh("")
 	if err != nil {
 		return nil, err
 	}
@@ -130,11 +247,21 @@ func NewAuthenticator(clientId, clientSecret, refreshToken string) (*Authenticat
 	return ator, nil
 }
 
+// NewStaticAuthenticator returns an authenticator that uses the given access token, e.g. a short-lived one minted by
+// a secrets broker, until the API rejects it.
+func NewStaticAuthenticator(accessToken string) *Authenticator {
+	return &Authenticator{
+		tkns:   &tokens{AccessToken: accessToken},
+		static: true,
+	}
+}
+
 // GenerateRefreshToken returns a refresh token given the specified client id, client secret and genrate code token.
 // If acquiring the refresh token fails then it returns an error.
-func GenerateRefreshToken(clientId, clientSecret, generateCode string) (string, error) {
+func GenerateRefreshToken(clientId, clientSecret, generateCode string, opts Options) (string, error) {
 	ator := &Authenticator{
 		tkns: &tokens{},
+		opts: opts,
 	}
 	ator.tkns.ClientId = clientId
 	ator.tkns.ClientSecret = clientSecret
@@ -0,0 +1,98 @@
+package oauth
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTokenServer returns a Zoho accounts server that issues numbered access tokens.
+func newTokenServer(t *testing.T, issued *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tokenApi {
+			t.Errorf("unexpected token request path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("refresh_token") != "refresh" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": "invalid_code"})
+			return
+		}
+		n := atomic.AddInt32(issued, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":   fmt.Sprintf("token-%d", n),
+			"expires_in_sec": 3600,
+		})
+	}))
+}
+
+func TestNewAuthenticatorError(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, &issued)
+	defer srv.Close()
+
+	_, err := NewAuthenticator("id", "secret", "wrong", Options{AccountsURL: srv.URL})
+	if err == nil || err.Error() != "invalid_code" {
+		t.Fatalf("got error %v, want invalid_code", err)
+	}
+}
+
+func TestAuthenticatorTokenCache(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, &issued)
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "site24x7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	opts := Options{AccountsURL: srv.URL, TokenCacheDir: dir}
+
+	for i := 0; i < 2; i++ {
+		ator, err := NewAuthenticator("id", "secret", "refresh", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok, _ := ator.Token(); tok != "token-1" {
+			t.Fatalf("got token %q from authenticator %d, want cached token-1", tok, i)
+		}
+	}
+	if issued != 1 {
+		t.Errorf("issued %d tokens, want 1", issued)
+	}
+
+	ator, err := NewAuthenticator("id", "secret", "refresh", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := ator.Renew("token-1"); tok != "token-2" {
+		t.Fatalf("got token %q after renewing, want token-2", tok)
+	}
+	ator, err = NewAuthenticator("id", "secret", "refresh", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := ator.Token(); tok != "token-2" {
+		t.Fatalf("got token %q, want renewed token-2 from cache", tok)
+	}
+
+	fi, err := os.Stat(ator.cache.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := fi.Mode().Perm(); perm != 0600 {
+		t.Errorf("cache file has mode %v, want 0600", perm)
+	}
+	b, err := ioutil.ReadFile(ator.cache.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "secret") || strings.Contains(string(b), "refresh") {
+		t.Errorf("cache file contains credentials: %s", b)
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"time"
 )
 
+// expiryDelta is how long before its actual expiration an access token is considered expired, so that
+// requests in flight don't race the expiration.
+const expiryDelta = time.Minute
+
 type tokens struct {
-	ClientId            string  `json:"CLIENT_ID"`
-	ClientSecret        string  `json:"CLIENT_SECRET"`
-	GeneratedCode       string  `json:"GENERATED_CODE"`
-	RefreshToken        string  `json:"REFRESH_TOKEN"`
-	AccessToken         string  `json:"ACCESS_TOKEN"`
-	TokenGenerationTime int64   `json:"TOKEN_GENERATION_TIME"`
-	ExpiresInSec        float64 `json:"EXPIRES_IN_SEC"`
+	ClientId            string  `json:"CLIENT_ID,omitempty"`
+	ClientSecret        string  `json:"CLIENT_SECRET,omitempty"`
+	GeneratedCode       string  `json:"GENERATED_CODE,omitempty"`
+	RefreshToken        string  `json:"REFRESH_TOKEN,omitempty"`
+	AccessToken         string  `json:"ACCESS_TOKEN,omitempty"`
+	TokenGenerationTime int64   `json:"TOKEN_GENERATION_TIME,omitempty"`
+	ExpiresInSec        float64 `json:"EXPIRES_IN_SEC,omitempty"`
 }
 
 func (tkns *tokens) refreshTokenURLValues() url.Values {
@@ -34,6 +38,11 @@ func (tkns *tokens) generatedCodeURLValues() url.Values {
 }
 
 func (tkns *tokens) expired() bool {
+	return tkns.expiresWithin(expiryDelta)
+}
+
+// expiresWithin reports whether the access token expires within d.
+func (tkns *tokens) expiresWithin(d time.Duration) bool {
 	now := time.Now().UnixNano() / 1e6
-	return now-tkns.TokenGenerationTime > int64(tkns.ExpiresInSec)*1000
+	return now-tkns.TokenGenerationTime > int64(tkns.ExpiresInSec)*1000-int64(d/time.Millisecond)
 }
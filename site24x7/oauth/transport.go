@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper that authorizes requests with an access token obtained from an
+// Authenticator. The token is refreshed transparently when it expires and a request that the API
+// rejects as unauthorized is retried once with a renewed token.
+type Transport struct {
+	// Base is the transport used to make requests. If nil, http.DefaultTransport is used.
+	Base          http.RoundTripper
+	Authenticator *Authenticator
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Authenticator.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base().RoundTrip(authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The token was revoked or expired earlier than advertised. The request can only be replayed if its body
+	// can be read again.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	token, err = t.Authenticator.Renew(token)
+	if err != nil {
+		return resp, nil
+	}
+	retry := authorize(req, token)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+
+	return t.base().RoundTrip(retry)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// authorize returns a copy of req carrying the access token, since a RoundTripper must not modify the request.
+func authorize(req *http.Request, token string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Zoho-oauthtoken "+token)
+	return r
+}
@@ -0,0 +1,121 @@
+package oauth
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAuthenticatorToken(t *testing.T) {
+	var issued int32
+	srv := newTokenServer(t, &issued)
+	defer srv.Close()
+
+	ator, err := NewAuthenticator("id", "secret", "refresh", Options{AccountsURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tok, _ := ator.Token(); tok != "token-1" {
+		t.Fatalf("got token %q, want token-1", tok)
+	}
+
+	ator.tkns.TokenGenerationTime -= 3600 * 1000
+	if tok, _ := ator.Token(); tok != "token-2" {
+		t.Fatalf("got token %q after expiry, want token-2", tok)
+	}
+}
+
+// TestAuthenticatorRefreshBackoff checks that a failed refresh isn't retried for every request, and that the current
+// token is used meanwhile while it's still valid.
+func TestAuthenticatorRefreshBackoff(t *testing.T) {
+	var requests, failing int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			json.NewEncoder(w).Encode(map[string]interface{}{"error": "Access Denied"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token", "expires_in_sec": 3600})
+	}))
+	defer srv.Close()
+
+	ator, err := NewAuthenticator("id", "secret", "refresh", Options{AccountsURL: srv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&failing, 1)
+
+	// within the expiry margin, the current token is still used
+	ator.tkns.TokenGenerationTime -= (3600*time.Second - expiryDelta/2).Nanoseconds() / 1e6
+	for i := 0; i < 3; i++ {
+		if tok, err := ator.Token(); err != nil || tok != "token" {
+			t.Fatalf("got token %q, error %v, want the current token", tok, err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("got %d token requests, want 2", requests)
+	}
+
+	// past the expiration, the refresh error is returned
+	ator.tkns.TokenGenerationTime -= 3600 * 1000
+	if _, err := ator.Token(); err == nil || err.Error() != "Access Denied" {
+		t.Fatalf("got error %v, want Access Denied", err)
+	}
+	if requests != 2 {
+		t.Errorf("got %d token requests within the retry interval, want 2", requests)
+	}
+
+	atomic.StoreInt32(&failing, 0)
+	ator.retryAt = time.Now()
+	if tok, err := ator.Token(); err != nil || tok != "token" {
+		t.Fatalf("got token %q, error %v after the retry interval, want token", tok, err)
+	}
+	if requests != 3 {
+		t.Errorf("got %d token requests, want 3", requests)
+	}
+}
+
+func TestTransportRetriesUnauthorized(t *testing.T) {
+	var issued int32
+	tokenSrv := newTokenServer(t, &issued)
+	defer tokenSrv.Close()
+
+	var calls int32
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("got body %q, want payload", body)
+		}
+		if r.Header.Get("Authorization") != "Zoho-oauthtoken token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiSrv.Close()
+
+	ator, err := NewAuthenticator("id", "secret", "refresh", Options{AccountsURL: tokenSrv.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: &Transport{Authenticator: ator}}
+
+	resp, err := client.Post(apiSrv.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if calls != 2 {
+		t.Errorf("got %d API calls, want 2", calls)
+	}
+}
@@ -1,10 +1,15 @@
 package site24x7
 
 import (
-	"net/http"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/httpclient"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
 	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
 )
 
@@ -13,26 +18,152 @@ func Provider() terraform.ResourceProvider {
 		Schema: map[string]*schema.Schema{
 			"oauth_client_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_CLIENT_ID", nil),
+				Sensitive:   true,
 				Description: "Zoho Site24x7 OAuth2 client id.",
 			},
 			"oauth_client_secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_CLIENT_SECRET", nil),
+				Sensitive:   true,
 				Description: "Zoho Site24x7 OAuth2 client secret.",
 			},
 			"oauth_refresh_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_REFRESH_TOKEN", nil),
+				Sensitive:   true,
 				Description: "Zoho Site24x7 OAuth2 refresh token.",
 			},
+			"oauth_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_CREDENTIALS_FILE", nil),
+				Description: "JSON file with the keys CLIENT_ID, CLIENT_SECRET and REFRESH_TOKEN, or ACCESS_TOKEN. Takes precedence over the oauth_* arguments.",
+			},
+			"credential_process": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_CREDENTIAL_PROCESS", nil),
+				Description: "Command printing credentials in the format of oauth_credentials_file. Takes precedence over oauth_credentials_file.",
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_ACCESS_TOKEN", nil),
+				Sensitive:   true,
+				Description: "Zoho Site24x7 OAuth2 access token used as is, e.g. a short-lived one. Takes precedence over all other credentials.",
+			},
+			"data_center": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_DATA_CENTER", oauth.DefaultDataCenter),
+				ValidateFunc: validateDataCenter,
+				Description:  "Data center of the Site24x7 account, one of " + strings.Join(oauth.DataCenterNames(), ", ") + ".",
+			},
+			"api_base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_API_BASE_URL", nil),
+				Description: "Site24x7 API base URL, overrides the one of data_center.",
+			},
+			"accounts_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_ACCOUNTS_URL", nil),
+				Description: "Zoho accounts server URL used for OAuth2, overrides the one of data_center.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_MAX_RETRIES", 5),
+				ValidateFunc: validateIntBetween(0, 20),
+				Description:  "Number of times API requests failing with a transient error (throttling, server errors) are retried.",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_MAX_CONCURRENT_REQUESTS", 5),
+				ValidateFunc: validateIntBetween(0, 100),
+				Description:  "Maximum number of requests in flight at once, regardless of Terraform's parallelism. 0 disables the limit.",
+			},
+			"requests_per_minute": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_REQUESTS_PER_MINUTE", 0),
+				ValidateFunc: validateIntBetween(0, 10000),
+				Description:  "Maximum number of requests started per minute. 0 disables the limit.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_REQUEST_TIMEOUT", 30),
+				ValidateFunc: validateIntBetween(1, 600),
+				Description:  "Timeout in seconds of each attempt of a request to the Site24x7 API and the Zoho accounts server. Waiting for retries and request limits doesn't count.",
+			},
+			"connect_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SITE24X7_CONNECT_TIMEOUT", 10),
+				ValidateFunc: validateIntBetween(1, 600),
+				Description:  "Timeout in seconds of establishing a connection, including the TLS handshake.",
+			},
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_CA_FILE", nil),
+				Description: "PEM file of certificate authorities to trust in addition to the system ones, e.g. for a TLS-intercepting proxy.",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_INSECURE", false),
+				Description: "Disable TLS certificate verification. Only meant for debugging.",
+			},
+			"token_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SITE24X7_TOKEN_CACHE_DIR", nil),
+				Description: "Directory where OAuth2 access tokens are cached and shared between Terraform runs, so that not every run uses the refresh token.",
+			},
+			"default_location_profile_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Location profile of monitors that don't specify one. Defaults to the first location profile of the account.",
+			},
+			"default_notification_profile_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Notification profile of monitors that don't specify one. Defaults to the first notification profile of the account.",
+			},
+			"default_threshold_profile_ids": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Threshold profiles of monitors that don't specify one, by monitor type (e.g. URL). Defaults to the first threshold profile of the account for the type.",
+			},
+			"default_user_group_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "User groups alerted by monitors that don't specify any. Defaults to the first user group of the account.",
+			},
+			"auto_create_default_profiles": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Create a minimal location profile, notification profile and user group to use as defaults if the account has none.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"site24x7_website_monitor": resourceSite24x7WebsiteMonitor(),
+			"site24x7_website_monitor":              resourceSite24x7WebsiteMonitor(),
+			"site24x7_rest_api_monitor":             resourceSite24x7RestAPIMonitor(),
+			"site24x7_rest_api_transaction_monitor": resourceSite24x7RestAPITransactionMonitor(),
+			"site24x7_ssl_certificate_monitor":      resourceSite24x7SSLCertificateMonitor(),
+			"site24x7_domain_expiry_monitor":        resourceSite24x7DomainExpiryMonitor(),
+			"site24x7_dns_server_monitor":           resourceSite24x7DNSServerMonitor(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -40,33 +171,109 @@ func Provider() terraform.ResourceProvider {
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	clientId := d.Get("oauth_client_id").(string)
-	clientSecret := d.Get("oauth_client_secret").(string)
-	refreshToken := d.Get("oauth_refresh_token").(string)
+	creds, err := providerCredentials(d)
+	if err != nil {
+		return nil, err
+	}
 
-	ator, err := oauth.NewAuthenticator(clientId, clientSecret, refreshToken)
+	dc, err := oauth.LookupDataCenter(d.Get("data_center").(string))
 	if err != nil {
 		return nil, err
 	}
+	if v := d.Get("api_base_url").(string); v != "" {
+		dc.APIBaseURL = v
+	}
+	if v := d.Get("accounts_url").(string); v != "" {
+		dc.AccountsURL = v
+	}
 
-	h := make(http.Header)
-	h.Set("Authorization", "Zoho-oauthtoken "+ator.AccessToken())
-	return &http.Client{
-		Transport: &staticHeaderTransport{
-			base:   http.DefaultTransport,
-			header: h,
-		},
+	httpClient, err := api.NewHTTPClient(api.HTTPOptions{
+		Timeout:        time.Duration(d.Get("request_timeout").(int)) * time.Second,
+		ConnectTimeout: time.Duration(d.Get("connect_timeout").(int)) * time.Second,
+		CAFile:         d.Get("ca_file").(string),
+		Insecure:       d.Get("insecure").(bool),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the client's transport times out each request, so waiting for the limit doesn't time requests out
+	httpClient.Transport = &api.LimitTransport{
+		Base:              httpClient.Transport,
+		MaxConcurrent:     d.Get("max_concurrent_requests").(int),
+		RequestsPerMinute: d.Get("requests_per_minute").(int),
+	}
+
+	ator, err := creds.NewAuthenticator(oauth.Options{
+		AccountsURL:   dc.AccountsURL,
+		HTTPClient:    httpClient,
+		TokenCacheDir: d.Get("token_cache_dir").(string),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := api.NewClient(api.Config{
+		BaseURL:       dc.APIBaseURL,
+		HTTPClient:    httpClient,
+		Authenticator: ator,
+		UserAgent:     httpclient.UserAgentString() + " terraform-provider-site24x7",
+		MaxRetries:    d.Get("max_retries").(int),
+	})
+
+	defaults := &profileDefaults{
+		client:                client,
+		autoCreate:            d.Get("auto_create_default_profiles").(bool),
+		locationProfileID:     d.Get("default_location_profile_id").(string),
+		notificationProfileID: d.Get("default_notification_profile_id").(string),
+		thresholdProfileIDs:   make(map[string]string),
+	}
+	for monitorType, id := range d.Get("default_threshold_profile_ids").(map[string]interface{}) {
+		defaults.thresholdProfileIDs[monitorType] = id.(string)
+	}
+	for _, id := range d.Get("default_user_group_ids").([]interface{}) {
+		defaults.userGroupIDs = append(defaults.userGroupIDs, id.(string))
+	}
+
+	return &providerMeta{
+		client:   client,
+		defaults: defaults,
 	}, nil
 }
 
-type staticHeaderTransport struct {
-	base   http.RoundTripper
-	header http.Header
+// providerCredentials returns the credentials of the first configured source, in order of precedence:
+// access_token, credential_process, oauth_credentials_file and the oauth_* arguments.
+func providerCredentials(d *schema.ResourceData) (*oauth.Credentials, error) {
+	if v := d.Get("access_token").(string); v != "" {
+		return &oauth.Credentials{AccessToken: v}, nil
+	}
+	if v := d.Get("credential_process").(string); v != "" {
+		return oauth.RunCredentialProcess(v)
+	}
+	if v := d.Get("oauth_credentials_file").(string); v != "" {
+		return oauth.ReadCredentialsFile(v)
+	}
+
+	creds := &oauth.Credentials{
+		ClientId:     d.Get("oauth_client_id").(string),
+		ClientSecret: d.Get("oauth_client_secret").(string),
+		RefreshToken: d.Get("oauth_refresh_token").(string),
+	}
+	if creds.ClientId == "" || creds.ClientSecret == "" || creds.RefreshToken == "" {
+		return nil, errors.New("no credentials configured: set access_token, credential_process, oauth_credentials_file " +
+			"or oauth_client_id, oauth_client_secret and oauth_refresh_token")
+	}
+	return creds, nil
+}
+
+// providerMeta is passed to the resource functions.
+type providerMeta struct {
+	client   *api.Client
+	defaults *profileDefaults
 }
 
-func (t *staticHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	for k, v := range t.header {
-		req.Header[k] = v
+func validateDataCenter(v interface{}, k string) (ws []string, errs []error) {
+	if _, err := oauth.LookupDataCenter(v.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", k, err))
 	}
-	return t.base.RoundTrip(req)
+	return
 }
@@ -1,15 +1,27 @@
 package site24x7
 
 import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
 	"testing"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/fake"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/oauth"
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
+// testFakeServer is the fake Site24x7 API the tests run against, unless real credentials are set in the
+// environment.
+var testFakeServer *fake.Server
+
 func init() {
 	testAccProvider = Provider().(*schema.Provider)
 	testAccProviders = map[string]terraform.ResourceProvider{
@@ -17,6 +29,24 @@ func init() {
 	}
 }
 
+func TestMain(m *testing.M) {
+	if os.Getenv("SITE24X7_CLIENT_ID") == "" {
+		testFakeServer = fake.NewServer()
+		os.Setenv("SITE24X7_CLIENT_ID", fake.ClientID)
+		os.Setenv("SITE24X7_CLIENT_SECRET", fake.ClientSecret)
+		os.Setenv("SITE24X7_REFRESH_TOKEN", fake.RefreshToken)
+		os.Setenv("SITE24X7_API_BASE_URL", testFakeServer.APIBaseURL())
+		os.Setenv("SITE24X7_ACCOUNTS_URL", testFakeServer.AccountsURL())
+	}
+
+	code := m.Run()
+
+	if testFakeServer != nil {
+		testFakeServer.Close()
+	}
+	os.Exit(code)
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -27,5 +57,176 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+// testAccTest runs c against the fake API, or against Site24x7 as an acceptance test (requiring TF_ACC) if real
+// credentials are set in the environment.
+func testAccTest(t *testing.T, c resource.TestCase) {
+	c.IsUnitTest = testFakeServer != nil
+	resource.Test(t, c)
+}
+
+// testAccClient returns the API client of the configured test provider.
+func testAccClient() *api.Client {
+	return testAccProvider.Meta().(*providerMeta).client
+}
+
+// checkMonitorExists returns a check that the monitor of the named resource exists.
+func checkMonitorExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		exists, err := testAccClient().MonitorExists(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("monitor %s not found", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// checkMonitorAPI checks fields of the monitor of the named resource as stored by the fake API, if the test runs
+// against it. A nil value checks that the field is absent.
+func checkMonitorAPI(name string, want map[string]interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testFakeServer == nil {
+			return nil
+		}
+		id := s.RootModule().Resources[name].Primary.ID
+		m, _ := testFakeServer.Get("monitors", id)
+		for k, v := range want {
+			if m[k] != v {
+				return fmt.Errorf("got %s %v in API, want %v", k, m[k], v)
+			}
+		}
+		return nil
+	}
+}
+
+// checkMonitorsDestroyed checks that the monitors of all resources of the given type in the state are gone.
+func checkMonitorsDestroyed(resourceType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			exists, err := testAccClient().MonitorExists(rs.Primary.ID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("monitor %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
+	for _, name := range []string{"SITE24X7_CLIENT_ID", "SITE24X7_CLIENT_SECRET", "SITE24X7_REFRESH_TOKEN"} {
+		if os.Getenv(name) == "" {
+			t.Fatalf("%s must be set for acceptance tests", name)
+		}
+	}
+}
+
+// validationCase is an invalid configuration of a resource: the arguments besides display_name, and a regular
+// expression matching the error planning it fails with.
+type validationCase struct {
+	attrs string
+	err   string
+}
+
+// testValidation checks that planning a resource of resourceType fails as expected for each case.
+func testValidation(t *testing.T, resourceType string, cases map[string]validationCase) {
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := fmt.Sprintf(`
+				resource %q "test" {
+					display_name = "invalid"
+					%s
+				}
+			`, resourceType, c.attrs)
+
+			testAccTest(t, resource.TestCase{
+				PreCheck:  func() { testAccPreCheck(t) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					resource.TestStep{
+						Config:      config,
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(c.err),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestProviderCredentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+	s := Provider().(*schema.Provider).Schema
+
+	cases := []struct {
+		name string
+		raw  map[string]interface{}
+		want oauth.Credentials
+	}{
+		{
+			name: "access token",
+			raw: map[string]interface{}{
+				"access_token":       "token",
+				"credential_process": `echo '{"ACCESS_TOKEN": "other"}'`,
+			},
+			want: oauth.Credentials{AccessToken: "token"},
+		},
+		{
+			name: "credential process",
+			raw: map[string]interface{}{
+				"credential_process":     `echo '{"CLIENT_ID": "id", "CLIENT_SECRET": "secret", "REFRESH_TOKEN": "refresh"}'`,
+				"oauth_credentials_file": "missing.json",
+			},
+			want: oauth.Credentials{ClientId: "id", ClientSecret: "secret", RefreshToken: "refresh"},
+		},
+		{
+			name: "arguments",
+			raw: map[string]interface{}{
+				"oauth_client_id":     "id",
+				"oauth_client_secret": "secret",
+				"oauth_refresh_token": "refresh",
+			},
+			want: oauth.Credentials{ClientId: "id", ClientSecret: "secret", RefreshToken: "refresh"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			creds, err := providerCredentials(schema.TestResourceDataRaw(t, s, c.raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *creds != c.want {
+				t.Errorf("got credentials %+v, want %+v", *creds, c.want)
+			}
+		})
+	}
+
+	_, err := providerCredentials(schema.TestResourceDataRaw(t, s, map[string]interface{}{
+		"oauth_credentials_file": "missing.json",
+	}))
+	if err == nil {
+		t.Error("got no error for missing credentials file")
+	}
+}
+
+func TestProviderValidateMaxRetries(t *testing.T) {
+	validate := Provider().(*schema.Provider).Schema["max_retries"].ValidateFunc
+	for v, valid := range map[int]bool{0: true, 5: true, 20: true, -1: false, 1000: false} {
+		if _, errs := validate(v, "max_retries"); (len(errs) == 0) != valid {
+			t.Errorf("%d: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
 }
@@ -0,0 +1,57 @@
+package site24x7
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// handleReadError handles the error of reading the resource d of the given kind (e.g. "website monitor"). If the
+// resource was deleted outside of Terraform it is removed from the state, so that Terraform plans to create it
+// again, and no error is returned.
+func handleReadError(d *schema.ResourceData, kind string, err error) error {
+	if api.IsNotFound(err) {
+		log.Printf("[WARN] %s %s not found, removing it from state", kind, d.Id())
+		d.SetId("")
+		return nil
+	}
+	return fmt.Errorf("error reading %s %s: %w", kind, d.Id(), err)
+}
+
+// handleDeleteError handles the error of deleting the resource d of the given kind. A resource that is already
+// gone is not an error.
+func handleDeleteError(d *schema.ResourceData, kind string, err error) error {
+	if err == nil || api.IsNotFound(err) {
+		return nil
+	}
+	return fmt.Errorf("error deleting %s %s: %w", kind, d.Id(), err)
+}
+
+// defaultWriteTimeout is how long creating or updating a resource may take, including waiting for it to be
+// readable.
+const defaultWriteTimeout = 2 * time.Minute
+
+// readAfterWrite calls read, which fetches the resource d of the given kind and updates d with it, to populate the
+// state with the values the API stored after a create or update. The API is eventually consistent, so read is
+// retried until the timeout while it fails with a not-found error.
+func readAfterWrite(d *schema.ResourceData, kind string, timeout time.Duration, read func() error) error {
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		err := read()
+		if api.IsNotFound(err) {
+			log.Printf("[DEBUG] %s %s not readable yet: %v", kind, d.Id(), err)
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reading %s %s after writing it: %w", kind, d.Id(), err)
+	}
+	return nil
+}
@@ -0,0 +1,466 @@
+package site24x7
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+func resourceSite24x7RestAPIMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: restAPIMonitorCreate,
+		Read:   restAPIMonitorRead,
+		Update: restAPIMonitorUpdate,
+		Delete: restAPIMonitorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("RESTAPI"),
+		},
+
+		CustomizeDiff: validateRestAPIAssertions,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"website": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHTTPURL,
+				DiffSuppressFunc: suppressEquivalentURL,
+			},
+
+			"http_method": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "G",
+				ValidateFunc: validateHTTPMethod,
+				StateFunc:    normalizeHTTPMethod,
+			},
+
+			"request_body": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// the API keeps the content type if it is omitted from an update, and it can't be cleared
+			"request_content_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateStringInSlice(contentTypeNames...),
+			},
+
+			"custom_headers": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"user_agent": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntBetween(1, 45),
+			},
+
+			"auth_user": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"bearer_token", "oauth2"},
+			},
+
+			"auth_pass": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"bearer_token", "oauth2"},
+			},
+
+			"bearer_token": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"oauth2"},
+			},
+
+			"oauth2": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_url": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHTTPURL,
+						},
+						"client_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"client_secret": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"scope": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"assertion": restAPIAssertionSchema(),
+
+			"accepted_status_codes": restAPIStatusCodesSchema(),
+
+			"json_schema": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schema": &schema.Schema{
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateJSON,
+							DiffSuppressFunc: suppressEquivalentJSON,
+						},
+						"severity": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      api.Trouble.String(),
+							ValidateFunc: validateStringInSlice(api.Down.String(), api.Trouble.String()),
+						},
+					},
+				},
+			},
+
+			"use_name_server": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		}),
+	}
+}
+
+// contentTypes maps the names of request content types to their API codes.
+var contentTypes = map[string]string{
+	"json": api.ContentTypeJSON,
+	"xml":  api.ContentTypeXML,
+	"text": api.ContentTypeText,
+	"form": api.ContentTypeForm,
+}
+
+var contentTypeNames = []string{"json", "xml", "text", "form"}
+
+// restAPIAssertionSchema returns the schema of JSONPath and XPath assertions on the response of a REST API request.
+func restAPIAssertionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateStringInSlice("jsonpath", "xpath"),
+				},
+				"expression": &schema.Schema{
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateNotEmpty,
+				},
+				"severity": &schema.Schema{
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      api.Trouble.String(),
+					ValidateFunc: validateStringInSlice(api.Down.String(), api.Trouble.String()),
+				},
+			},
+		},
+	}
+}
+
+// restAPIStatusCodesSchema returns the schema of the status codes accepted in response to a REST API request.
+func restAPIStatusCodesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type: schema.TypeList,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateStatusCodeRange,
+		},
+		Optional: true,
+	}
+}
+
+func restAPIMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	return restAPIMonitorCreateOrUpdate(d, meta)
+}
+
+func restAPIMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	return restAPIMonitorCreateOrUpdate(d, meta)
+}
+
+func restAPIMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m := restAPIMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "RESTAPI", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateRestAPIMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating REST API monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		if err := client.UpdateRestAPIMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating REST API monitor %s: %w", d.Id(), err)
+		}
+	}
+
+	return readAfterWrite(d, "REST API monitor", timeout, func() error {
+		m, err := client.GetRestAPIMonitor(d.Id())
+		if err != nil {
+			return err
+		}
+		updateRestAPIMonitorResourceData(d, m)
+		return nil
+	})
+}
+
+// restAPIMonitorFromResourceData builds the API representation of the monitor configured in d.
+func restAPIMonitorFromResourceData(d *schema.ResourceData) *api.RestAPIMonitor {
+	m := &api.RestAPIMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "RESTAPI",
+		Website:               d.Get("website").(string),
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		Timeout:               d.Get("timeout").(int),
+		HTTPMethod:            normalizeHTTPMethod(d.Get("http_method")),
+		RequestContentType:    contentTypes[d.Get("request_content_type").(string)],
+		RequestParam:          clearableString(d, "request_body"),
+		CustomHeaders:         customHeaders(d.Get("custom_headers")),
+		UserAgent:             d.Get("user_agent").(string),
+		AuthMethod:            api.AuthNone,
+		UpStatusCodes:         strings.Join(stringList(d.Get("accepted_status_codes")), ","),
+		LocationProfileID:     d.Get("location_profile_id").(string),
+		NotificationProfileID: d.Get("notification_profile_id").(string),
+		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
+		UseNameServer:         d.Get("use_name_server").(bool),
+	}
+
+	switch {
+	case d.Get("auth_user").(string) != "":
+		m.AuthMethod = api.AuthBasic
+		m.AuthUser = d.Get("auth_user").(string)
+		if authPass, ok := writeOnlySecret(d, "auth_pass"); ok {
+			m.AuthPass = &authPass
+		}
+	case d.Get("bearer_token").(string) != "":
+		m.AuthMethod = api.AuthBearer
+		if token, ok := writeOnlySecret(d, "bearer_token"); ok {
+			m.BearerToken = &token
+		}
+	case len(d.Get("oauth2").([]interface{})) > 0:
+		m.AuthMethod = api.AuthOAuth2
+		m.OAuth2 = &api.OAuth2ClientCredentials{
+			GrantType: "client_credentials",
+			TokenURL:  d.Get("oauth2.0.token_url").(string),
+			ClientID:  d.Get("oauth2.0.client_id").(string),
+			Scope:     d.Get("oauth2.0.scope").(string),
+		}
+		if secret, ok := writeOnlySecret(d, "oauth2.0.client_secret"); ok {
+			m.OAuth2.ClientSecret = &secret
+		}
+	}
+
+	m.MatchJSON, m.MatchXML, m.ResponseContentType = pathAssertions(d.Get("assertion").([]interface{}))
+
+	if v := d.Get("json_schema").([]interface{}); len(v) > 0 {
+		jsonSchema := v[0].(map[string]interface{})
+		// the severity was validated
+		severity, _ := api.ParseStatus(jsonSchema["severity"].(string))
+		m.JSONSchemaCheck = true
+		m.JSONSchema = &api.JSONSchema{SchemaValue: jsonSchema["schema"].(string), Severity: severity}
+		m.ResponseContentType = api.ContentTypeJSON
+	}
+
+	return m
+}
+
+// pathAssertions returns the JSONPath and XPath expressions of the given assertion blocks, and the response content
+// type they require.
+func pathAssertions(assertions []interface{}) (matchJSON, matchXML []api.PathAssertion, responseContentType string) {
+	matchJSON, matchXML = []api.PathAssertion{}, []api.PathAssertion{}
+	for _, v := range assertions {
+		assertion := v.(map[string]interface{})
+		// the severity was validated
+		severity, _ := api.ParseStatus(assertion["severity"].(string))
+		a := api.PathAssertion{Expression: assertion["expression"].(string), Severity: severity}
+		if assertion["type"] == "xpath" {
+			matchXML = append(matchXML, a)
+			responseContentType = api.ContentTypeXML
+		} else {
+			matchJSON = append(matchJSON, a)
+			responseContentType = api.ContentTypeJSON
+		}
+	}
+	return matchJSON, matchXML, responseContentType
+}
+
+// flattenPathAssertions returns the assertion blocks representing the given JSONPath and XPath expressions.
+func flattenPathAssertions(matchJSON, matchXML []api.PathAssertion) []map[string]interface{} {
+	var assertions []map[string]interface{}
+	for _, a := range matchJSON {
+		assertions = append(assertions, map[string]interface{}{
+			"type":       "jsonpath",
+			"expression": a.Expression,
+			"severity":   a.Severity.String(),
+		})
+	}
+	for _, a := range matchXML {
+		assertions = append(assertions, map[string]interface{}{
+			"type":       "xpath",
+			"expression": a.Expression,
+			"severity":   a.Severity.String(),
+		})
+	}
+	return assertions
+}
+
+// assertionTypes reports which types of assertion blocks are given.
+func assertionTypes(assertions []interface{}) (jsonPath, xPath bool) {
+	for _, v := range assertions {
+		if v.(map[string]interface{})["type"] == "xpath" {
+			xPath = true
+		} else {
+			jsonPath = true
+		}
+	}
+	return jsonPath, xPath
+}
+
+// errMixedAssertions is returned for JSONPath and XPath assertions on the same response, as the API parses the
+// response either as JSON or as XML.
+var errMixedAssertions = errors.New("jsonpath and xpath assertions can't be combined")
+
+// validateRestAPIAssertions checks that the assertions apply to a single response content type.
+func validateRestAPIAssertions(d *schema.ResourceDiff, meta interface{}) error {
+	jsonPath, xPath := assertionTypes(d.Get("assertion").([]interface{}))
+	if xPath && jsonPath {
+		return fmt.Errorf("assertion: %w", errMixedAssertions)
+	}
+	if xPath && len(d.Get("json_schema").([]interface{})) > 0 {
+		return errors.New("json_schema can't be combined with xpath assertions")
+	}
+	return nil
+}
+
+func restAPIMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m, err := client.GetRestAPIMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "REST API monitor", err)
+	}
+	updateRestAPIMonitorResourceData(d, m)
+
+	return nil
+}
+
+func updateRestAPIMonitorResourceData(d *schema.ResourceData, m *api.RestAPIMonitor) {
+	d.Set("display_name", m.DisplayName)
+	d.Set("website", m.Website)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
+	d.Set("timeout", m.Timeout)
+	d.Set("http_method", m.HTTPMethod)
+	d.Set("request_body", unfixEmpty(m.RequestParam))
+	d.Set("request_content_type", contentTypeName(m.RequestContentType))
+	d.Set("custom_headers", flattenCustomHeaders(m.CustomHeaders))
+	d.Set("user_agent", m.UserAgent)
+
+	// auth_pass, bearer_token and the OAuth2 client secret are write-only, the state keeps the configured values
+	d.Set("auth_user", "")
+	d.Set("oauth2", nil)
+	switch m.AuthMethod {
+	case api.AuthBasic:
+		d.Set("auth_user", m.AuthUser)
+	case api.AuthOAuth2:
+		if m.OAuth2 != nil {
+			d.Set("oauth2", []map[string]interface{}{{
+				"token_url":     m.OAuth2.TokenURL,
+				"client_id":     m.OAuth2.ClientID,
+				"client_secret": d.Get("oauth2.0.client_secret"),
+				"scope":         m.OAuth2.Scope,
+			}})
+		}
+	}
+
+	d.Set("assertion", flattenPathAssertions(m.MatchJSON, m.MatchXML))
+	d.Set("accepted_status_codes", splitStatusCodes(m.UpStatusCodes))
+
+	if m.JSONSchemaCheck && m.JSONSchema != nil {
+		d.Set("json_schema", []map[string]interface{}{{
+			"schema":   m.JSONSchema.SchemaValue,
+			"severity": m.JSONSchema.Severity.String(),
+		}})
+	} else {
+		d.Set("json_schema", nil)
+	}
+
+	d.Set("location_profile_id", m.LocationProfileID)
+	d.Set("notification_profile_id", m.NotificationProfileID)
+	d.Set("threshold_profile_id", m.ThresholdProfileID)
+	d.Set("monitor_groups", m.MonitorGroups)
+	d.Set("user_group_ids", m.UserGroupIDs)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
+	d.Set("use_name_server", m.UseNameServer)
+}
+
+// contentTypeName returns the name of the content type with the given API code.
+func contentTypeName(code string) string {
+	for name, c := range contentTypes {
+		if c == code {
+			return name
+		}
+	}
+	return ""
+}
+
+// splitStatusCodes splits the comma-separated status codes stored by the API.
+func splitStatusCodes(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func restAPIMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	return handleDeleteError(d, "REST API monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
+}
@@ -0,0 +1,153 @@
+package site24x7
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestRestAPIMonitor(t *testing.T) {
+	const config1 = `
+		resource "site24x7_rest_api_monitor" "test" {
+			display_name = "api"
+			website = "https://api.example.com/health"
+		}
+	`
+
+	const config2 = `
+		resource "site24x7_rest_api_monitor" "test" {
+			display_name = "api"
+			website = "https://api.example.com/items"
+			http_method = "POST"
+			request_content_type = "json"
+			request_body = "{\"name\": \"probe\"}"
+			custom_headers = { "X-Probe" = "1" }
+			bearer_token = "secret-token"
+			assertion {
+				type = "jsonpath"
+				expression = "$.id"
+			}
+			assertion {
+				type = "jsonpath"
+				expression = "$.name"
+				severity = "down"
+			}
+			accepted_status_codes = ["200", "201-204"]
+			json_schema {
+				schema = <<EOF
+{
+  "type": "object",
+  "required": ["id"]
+}
+EOF
+			}
+		}
+	`
+
+	const config3 = `
+		resource "site24x7_rest_api_monitor" "test" {
+			display_name = "api"
+			website = "https://api.example.com/items"
+			oauth2 {
+				token_url = "https://auth.example.com/token"
+				client_id = "probe"
+				client_secret = "probe-secret"
+				scope = "items:read"
+			}
+			assertion {
+				type = "xpath"
+				expression = "/items/item"
+			}
+		}
+	`
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_rest_api_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_rest_api_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "http_method", "G"),
+					resource.TestCheckResourceAttrSet("site24x7_rest_api_monitor.test", "threshold_profile_id"),
+					checkMonitorAPI("site24x7_rest_api_monitor.test", map[string]interface{}{"request_param": nil}),
+				),
+			},
+
+			resource.TestStep{
+				Config: config2,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_rest_api_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "assertion.#", "2"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "assertion.1.severity", "down"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "accepted_status_codes.1", "201-204"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "json_schema.0.severity", "trouble"),
+					checkMonitorAPI("site24x7_rest_api_monitor.test", map[string]interface{}{
+						"auth_method":           "T",
+						"bearer_token":          "secret-token",
+						"response_content_type": "J",
+						"up_status_codes":       "200,201-204",
+						"json_schema_check":     true,
+					}),
+				),
+			},
+
+			resource.TestStep{
+				Config: config2,
+				// reading back the monitor produces no diff
+				PlanOnly: true,
+			},
+
+			resource.TestStep{
+				Config: config3,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_rest_api_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "oauth2.0.client_secret", "probe-secret"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "json_schema.#", "0"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_monitor.test", "request_body", ""),
+					checkMonitorAPI("site24x7_rest_api_monitor.test", map[string]interface{}{
+						"auth_method":           "O",
+						"response_content_type": "X",
+						"json_schema_check":     false,
+						"request_param":         " ",
+					}),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:            "site24x7_rest_api_monitor.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auth_pass", "bearer_token", "oauth2.0.client_secret"},
+			},
+		},
+	})
+}
+
+func TestRestAPIMonitorValidation(t *testing.T) {
+	const website = `website = "https://api.example.com"`
+	testValidation(t, "site24x7_rest_api_monitor", map[string]validationCase{
+		"status code": {
+			attrs: website + "\n accepted_status_codes = [\"2xx\"]",
+			err:   `accepted_status_codes\.0 must be an HTTP status code`,
+		},
+		"json schema": {
+			attrs: website + "\n json_schema {\n schema = \"{\"\n }",
+			err:   `schema must be valid JSON`,
+		},
+		"auth": {
+			attrs: website + "\n auth_user = \"user\"\n bearer_token = \"token\"",
+			err:   `conflicts with`,
+		},
+		"mixed assertions": {
+			attrs: website + "\n assertion {\n type = \"jsonpath\"\n expression = \"$.a\"\n }\n assertion {\n type = \"xpath\"\n expression = \"/a\"\n }",
+			err:   `jsonpath and xpath assertions can't be combined`,
+		},
+		"json schema with xpath": {
+			attrs: website + "\n assertion {\n type = \"xpath\"\n expression = \"/a\"\n }\n json_schema {\n schema = \"{}\"\n }",
+			err:   `json_schema can't be combined with xpath assertions`,
+		},
+	})
+}
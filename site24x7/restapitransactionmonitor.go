@@ -0,0 +1,290 @@
+package site24x7
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// maxTransactionSteps is the maximum number of steps of a REST API transaction monitor.
+const maxTransactionSteps = 25
+
+func resourceSite24x7RestAPITransactionMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: restAPITransactionMonitorCreate,
+		Read:   restAPITransactionMonitorRead,
+		Update: restAPITransactionMonitorUpdate,
+		Delete: restAPITransactionMonitorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("RESTAPISEQ"),
+		},
+
+		CustomizeDiff: validateTransactionSteps,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"step": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				MaxItems: maxTransactionSteps,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateNotEmpty,
+						},
+						"url": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateHTTPURL,
+						},
+						"http_method": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "G",
+							ValidateFunc: validateHTTPMethod,
+							StateFunc:    normalizeHTTPMethod,
+						},
+						"request_content_type": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateStringInSlice(contentTypeNames...),
+						},
+						"request_body": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"custom_headers": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+						},
+						"timeout": &schema.Schema{
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validateIntBetween(1, 45),
+						},
+						"assertion":             restAPIAssertionSchema(),
+						"accepted_status_codes": restAPIStatusCodesSchema(),
+						"variable": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateIdentifier,
+									},
+									"type": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateStringInSlice(variableSourceNames...),
+									},
+									"expression": &schema.Schema{
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validateNotEmpty,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"use_name_server": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		}),
+	}
+}
+
+// variableSources maps the types of variable blocks to their API codes.
+var variableSources = map[string]string{
+	"jsonpath": api.VariableSourceJSONPath,
+	"regex":    api.VariableSourceRegex,
+	"header":   api.VariableSourceHeader,
+}
+
+var variableSourceNames = []string{"jsonpath", "regex", "header"}
+
+func restAPITransactionMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	return restAPITransactionMonitorCreateOrUpdate(d, meta)
+}
+
+func restAPITransactionMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	return restAPITransactionMonitorCreateOrUpdate(d, meta)
+}
+
+func restAPITransactionMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m := restAPITransactionMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "RESTAPISEQ", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateRestAPITransactionMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating REST API transaction monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		if err := client.UpdateRestAPITransactionMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating REST API transaction monitor %s: %w", d.Id(), err)
+		}
+	}
+
+	return readAfterWrite(d, "REST API transaction monitor", timeout, func() error {
+		m, err := client.GetRestAPITransactionMonitor(d.Id())
+		if err != nil {
+			return err
+		}
+		updateRestAPITransactionMonitorResourceData(d, m)
+		return nil
+	})
+}
+
+// restAPITransactionMonitorFromResourceData builds the API representation of the monitor configured in d.
+func restAPITransactionMonitorFromResourceData(d *schema.ResourceData) *api.RestAPITransactionMonitor {
+	m := &api.RestAPITransactionMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "RESTAPISEQ",
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		LocationProfileID:     d.Get("location_profile_id").(string),
+		NotificationProfileID: d.Get("notification_profile_id").(string),
+		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
+		UseNameServer:         d.Get("use_name_server").(bool),
+	}
+
+	for i, v := range d.Get("step").([]interface{}) {
+		step := v.(map[string]interface{})
+		s := api.RestAPITransactionStep{
+			StepOrder:          i + 1,
+			DisplayName:        step["name"].(string),
+			StepURL:            step["url"].(string),
+			HTTPMethod:         normalizeHTTPMethod(step["http_method"]),
+			RequestContentType: contentTypes[step["request_content_type"].(string)],
+			RequestParam:       step["request_body"].(string),
+			CustomHeaders:      customHeaders(step["custom_headers"]),
+			Timeout:            step["timeout"].(int),
+			UpStatusCodes:      strings.Join(stringList(step["accepted_status_codes"]), ","),
+			ResponseVariables:  []api.ResponseVariable{},
+		}
+		s.MatchJSON, s.MatchXML, s.ResponseContentType = pathAssertions(step["assertion"].([]interface{}))
+		for _, v := range step["variable"].([]interface{}) {
+			variable := v.(map[string]interface{})
+			s.ResponseVariables = append(s.ResponseVariables, api.ResponseVariable{
+				Name:       variable["name"].(string),
+				Source:     variableSources[variable["type"].(string)],
+				Expression: variable["expression"].(string),
+			})
+		}
+		m.Steps = append(m.Steps, s)
+	}
+
+	return m
+}
+
+// validateTransactionSteps checks the assertions of each step like validateRestAPIAssertions, and that variable
+// names are unique across the steps, as they share a namespace.
+func validateTransactionSteps(d *schema.ResourceDiff, meta interface{}) error {
+	defined := make(map[string]string)
+	for i, v := range d.Get("step").([]interface{}) {
+		step := v.(map[string]interface{})
+		if jsonPath, xPath := assertionTypes(step["assertion"].([]interface{})); jsonPath && xPath {
+			return fmt.Errorf("step.%d.assertion: %w", i, errMixedAssertions)
+		}
+		for _, v := range step["variable"].([]interface{}) {
+			name := v.(map[string]interface{})["name"].(string)
+			if other, ok := defined[name]; ok {
+				return fmt.Errorf("step.%d.variable: %q is already extracted in step %q", i, name, other)
+			}
+			defined[name] = step["name"].(string)
+		}
+	}
+	return nil
+}
+
+func restAPITransactionMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m, err := client.GetRestAPITransactionMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "REST API transaction monitor", err)
+	}
+	updateRestAPITransactionMonitorResourceData(d, m)
+
+	return nil
+}
+
+func updateRestAPITransactionMonitorResourceData(d *schema.ResourceData, m *api.RestAPITransactionMonitor) {
+	d.Set("display_name", m.DisplayName)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
+
+	steps := make([]map[string]interface{}, len(m.Steps))
+	for i, s := range m.Steps {
+		variables := make([]map[string]interface{}, len(s.ResponseVariables))
+		for j, v := range s.ResponseVariables {
+			variableType := ""
+			for name, source := range variableSources {
+				if source == v.Source {
+					variableType = name
+				}
+			}
+			variables[j] = map[string]interface{}{
+				"name":       v.Name,
+				"type":       variableType,
+				"expression": v.Expression,
+			}
+		}
+		steps[i] = map[string]interface{}{
+			"name":                  s.DisplayName,
+			"url":                   s.StepURL,
+			"http_method":           s.HTTPMethod,
+			"request_content_type":  contentTypeName(s.RequestContentType),
+			"request_body":          unfixEmpty(s.RequestParam),
+			"custom_headers":        flattenCustomHeaders(s.CustomHeaders),
+			"timeout":               s.Timeout,
+			"assertion":             flattenPathAssertions(s.MatchJSON, s.MatchXML),
+			"accepted_status_codes": splitStatusCodes(s.UpStatusCodes),
+			"variable":              variables,
+		}
+	}
+	d.Set("step", steps)
+
+	d.Set("location_profile_id", m.LocationProfileID)
+	d.Set("notification_profile_id", m.NotificationProfileID)
+	d.Set("threshold_profile_id", m.ThresholdProfileID)
+	d.Set("monitor_groups", m.MonitorGroups)
+	d.Set("user_group_ids", m.UserGroupIDs)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
+	d.Set("use_name_server", m.UseNameServer)
+}
+
+func restAPITransactionMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	return handleDeleteError(d, "REST API transaction monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
+}
@@ -0,0 +1,208 @@
+package site24x7
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestRestAPITransactionMonitor(t *testing.T) {
+	const login = `
+		step {
+			name = "login"
+			url = "https://api.example.com/login"
+			http_method = "POST"
+			request_content_type = "json"
+			request_body = "{\"user\": \"probe\"}"
+			timeout = 5
+			accepted_status_codes = ["200"]
+			variable {
+				name = "token"
+				type = "jsonpath"
+				expression = "$.token"
+			}
+		}
+	`
+	const create = `
+		step {
+			name = "create"
+			url = "https://api.example.com/items"
+			http_method = "POST"
+			custom_headers = { "Authorization" = "Bearer $${token}" }
+			variable {
+				name = "item_id"
+				type = "header"
+				expression = "Location"
+			}
+		}
+	`
+	const fetch = `
+		step {
+			name = "fetch"
+			url = "https://api.example.com/items/$${item_id}"
+			assertion {
+				type = "jsonpath"
+				expression = "$.id"
+				severity = "down"
+			}
+		}
+	`
+	config := func(steps ...string) string {
+		return fmt.Sprintf(`
+			resource "site24x7_rest_api_transaction_monitor" "test" {
+				display_name = "flow"
+				%s
+			}
+		`, strings.Join(steps, ""))
+	}
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_rest_api_transaction_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config(login + create + fetch),
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_rest_api_transaction_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.#", "3"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.0.name", "login"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.0.http_method", "P"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.0.timeout", "5"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.1.variable.0.type", "header"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.2.url", "https://api.example.com/items/${item_id}"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.2.assertion.0.severity", "down"),
+					resource.TestCheckResourceAttrSet("site24x7_rest_api_transaction_monitor.test", "threshold_profile_id"),
+					checkTransactionStepBodies("{\"user\": \"probe\"}", nil, nil),
+					reverseTransactionSteps,
+				),
+			},
+
+			resource.TestStep{
+				// the steps returned out of order by the API are read back in order
+				Config:   config(login + create + fetch),
+				PlanOnly: true,
+			},
+
+			resource.TestStep{
+				Config: config(login + fetch),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.#", "2"),
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.1.name", "fetch"),
+				),
+			},
+
+			resource.TestStep{
+				// the step moved to the front has no body, although the step it replaces had one
+				Config: config(fetch + login),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_rest_api_transaction_monitor.test", "step.0.request_body", ""),
+					checkTransactionStepBodies(nil, "{\"user\": \"probe\"}"),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_rest_api_transaction_monitor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// checkTransactionStepBodies checks the request bodies of the steps stored by the fake API, nil if there is none.
+func checkTransactionStepBodies(want ...interface{}) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testFakeServer == nil {
+			return nil
+		}
+		id := s.RootModule().Resources["site24x7_rest_api_transaction_monitor.test"].Primary.ID
+		m, _ := testFakeServer.Get("monitors", id)
+		for i, step := range m["steps"].([]interface{}) {
+			if got := step.(map[string]interface{})["request_param"]; got != want[i] {
+				return fmt.Errorf("got request_param %#v of step %d in API, want %#v", got, i, want[i])
+			}
+		}
+		return nil
+	}
+}
+
+// reverseTransactionSteps reverses the steps stored by the fake API, keeping their step_order, like the API may
+// return them.
+func reverseTransactionSteps(s *terraform.State) error {
+	if testFakeServer == nil {
+		return nil
+	}
+	id := s.RootModule().Resources["site24x7_rest_api_transaction_monitor.test"].Primary.ID
+	m, _ := testFakeServer.Get("monitors", id)
+	steps := m["steps"].([]interface{})
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return nil
+}
+
+func TestRestAPITransactionMonitorValidation(t *testing.T) {
+	testValidation(t, "site24x7_rest_api_transaction_monitor", map[string]validationCase{
+		"no steps": {
+			err: `At least 1 "step" blocks are required`,
+		},
+		"duplicate variable": {
+			attrs: `
+				step {
+					name = "a"
+					url = "https://api.example.com/a"
+					variable {
+						name = "id"
+						type = "regex"
+						expression = "id=(\\d+)"
+					}
+				}
+				step {
+					name = "b"
+					url = "https://api.example.com/b"
+					variable {
+						name = "id"
+						type = "jsonpath"
+						expression = "$.id"
+					}
+				}
+			`,
+			err: `step\.1\.variable: "id" is already extracted in step "a"`,
+		},
+		"variable name": {
+			attrs: `
+				step {
+					name = "a"
+					url = "https://api.example.com/a"
+					variable {
+						name = "item-id"
+						type = "jsonpath"
+						expression = "$.id"
+					}
+				}
+			`,
+			err: `must consist of letters, digits and underscores`,
+		},
+		"mixed assertions": {
+			attrs: `
+				step {
+					name = "a"
+					url = "https://api.example.com/a"
+					assertion {
+						type = "jsonpath"
+						expression = "$.a"
+					}
+					assertion {
+						type = "xpath"
+						expression = "/a"
+					}
+				}
+			`,
+			err: `step\.0\.assertion: jsonpath and xpath assertions can't be combined`,
+		},
+	})
+}
@@ -0,0 +1,41 @@
+package site24x7
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// hashedSecretPrefix marks secrets of which only a hash is stored in the state.
+const hashedSecretPrefix = "sha256:"
+
+// hashSecret returns the representation of secret stored in the state when only its hash is kept.
+func hashSecret(secret string) string {
+	if secret == "" || isHashedSecret(secret) {
+		return secret
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return hashedSecretPrefix + hex.EncodeToString(sum[:])
+}
+
+func isHashedSecret(s string) bool {
+	return strings.HasPrefix(s, hashedSecretPrefix)
+}
+
+// suppressHashedSecret returns a function suppressing the diff between a secret in the configuration and its hash in
+// the state while the boolean attribute hashKey is set, so that only a changed secret produces a diff. Once hashKey
+// is unset the hash differs from the secret, so the secret is written and stored in the state again.
+func suppressHashedSecret(hashKey string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return d.Get(hashKey).(bool) && isHashedSecret(old) && old == hashSecret(new)
+	}
+}
+
+// writeOnlySecret returns the value of the secret attribute key to send to the API, and whether it is known. It is
+// unknown if only its hash is in the state and it didn't change, in which case it shouldn't be sent.
+func writeOnlySecret(d *schema.ResourceData, key string) (string, bool) {
+	v := d.Get(key).(string)
+	return v, !isHashedSecret(v)
+}
@@ -0,0 +1,205 @@
+package site24x7
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// tlsVersions are the protocol versions an SSL certificate monitor can require.
+var tlsVersions = []string{"TLSv1.0", "TLSv1.1", "TLSv1.2", "TLSv1.3"}
+
+func resourceSite24x7SSLCertificateMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create: sslCertificateMonitorCreate,
+		Read:   sslCertificateMonitorRead,
+		Update: sslCertificateMonitorUpdate,
+		Delete: sslCertificateMonitorDelete,
+
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("SSL_CERT"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"domain": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateHostname,
+			},
+
+			"port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      443,
+				ValidateFunc: validateIntBetween(1, 65535),
+			},
+
+			"timeout": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntBetween(1, 45),
+			},
+
+			"expiry_alert_days": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validateIntBetween(1, 365),
+				Description:  "Alert this many days before the certificate expires.",
+			},
+
+			"protocol_versions": &schema.Schema{
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateStringInSlice(tlsVersions...),
+				},
+				Optional:    true,
+				Description: "Alert if the server negotiates a protocol version not in this set. Any version is accepted if empty.",
+			},
+
+			"fingerprint": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateFingerprint,
+				StateFunc:    normalizeFingerprint,
+				Description:  "Alert if the SHA-256 fingerprint of the certificate differs, e.g. when it is replaced.",
+			},
+
+			"issuer": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Alert if the name of the certificate issuer differs.",
+			},
+
+			"ignore_trust": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Don't alert for certificates that aren't trusted, e.g. self-signed ones.",
+			},
+
+			"ignore_domain_mismatch": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Don't alert for certificates not issued for domain.",
+			},
+		}),
+	}
+}
+
+func sslCertificateMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	return sslCertificateMonitorCreateOrUpdate(d, meta)
+}
+
+func sslCertificateMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	return sslCertificateMonitorCreateOrUpdate(d, meta)
+}
+
+func sslCertificateMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m := sslCertificateMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "SSL_CERT", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateSSLCertificateMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating SSL certificate monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		if err := client.UpdateSSLCertificateMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating SSL certificate monitor %s: %w", d.Id(), err)
+		}
+	}
+
+	return readAfterWrite(d, "SSL certificate monitor", timeout, func() error {
+		m, err := client.GetSSLCertificateMonitor(d.Id())
+		if err != nil {
+			return err
+		}
+		updateSSLCertificateMonitorResourceData(d, m)
+		return nil
+	})
+}
+
+// sslCertificateMonitorFromResourceData builds the API representation of the monitor configured in d.
+func sslCertificateMonitorFromResourceData(d *schema.ResourceData) *api.SSLCertificateMonitor {
+	fingerprint := clearableString(d, "fingerprint")
+	if unfixEmpty(fingerprint) != "" {
+		fingerprint = normalizeFingerprint(fingerprint)
+	}
+
+	return &api.SSLCertificateMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "SSL_CERT",
+		DomainName:            d.Get("domain").(string),
+		Port:                  d.Get("port").(int),
+		Timeout:               d.Get("timeout").(int),
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		ExpireDays:            d.Get("expiry_alert_days").(int),
+		ProtocolVersions:      stringList(d.Get("protocol_versions")),
+		CertFingerprint:       fingerprint,
+		IssuerName:            clearableString(d, "issuer"),
+		IgnoreTrust:           d.Get("ignore_trust").(bool),
+		IgnoreDomainMismatch:  d.Get("ignore_domain_mismatch").(bool),
+		LocationProfileID:     d.Get("location_profile_id").(string),
+		NotificationProfileID: d.Get("notification_profile_id").(string),
+		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
+	}
+}
+
+func sslCertificateMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
+
+	m, err := client.GetSSLCertificateMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "SSL certificate monitor", err)
+	}
+	updateSSLCertificateMonitorResourceData(d, m)
+
+	return nil
+}
+
+func updateSSLCertificateMonitorResourceData(d *schema.ResourceData, m *api.SSLCertificateMonitor) {
+	d.Set("display_name", m.DisplayName)
+	d.Set("domain", m.DomainName)
+	d.Set("port", m.Port)
+	d.Set("timeout", m.Timeout)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
+	d.Set("expiry_alert_days", m.ExpireDays)
+	d.Set("protocol_versions", m.ProtocolVersions)
+	d.Set("fingerprint", normalizeFingerprint(unfixEmpty(m.CertFingerprint)))
+	d.Set("issuer", unfixEmpty(m.IssuerName))
+	d.Set("ignore_trust", m.IgnoreTrust)
+	d.Set("ignore_domain_mismatch", m.IgnoreDomainMismatch)
+	d.Set("location_profile_id", m.LocationProfileID)
+	d.Set("notification_profile_id", m.NotificationProfileID)
+	d.Set("threshold_profile_id", m.ThresholdProfileID)
+	d.Set("monitor_groups", m.MonitorGroups)
+	d.Set("user_group_ids", m.UserGroupIDs)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
+}
+
+func sslCertificateMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	return handleDeleteError(d, "SSL certificate monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
+}
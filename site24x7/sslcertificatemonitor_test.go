@@ -0,0 +1,101 @@
+package site24x7
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestSSLCertificateMonitor(t *testing.T) {
+	const config1 = `
+		resource "site24x7_ssl_certificate_monitor" "test" {
+			display_name = "certificate"
+			domain = "www.sourcegraph.com"
+		}
+	`
+
+	const config2 = `
+		resource "site24x7_ssl_certificate_monitor" "test" {
+			display_name = "certificate"
+			domain = "sourcegraph.com"
+			port = 8443
+			expiry_alert_days = 14
+			protocol_versions = ["TLSv1.2", "TLSv1.3"]
+			fingerprint = "ab:cd:ef:01:23:45:67:89:ab:cd:ef:01:23:45:67:89:ab:cd:ef:01:23:45:67:89:ab:cd:ef:01:23:45:67:89"
+			issuer = "Let's Encrypt"
+			ignore_domain_mismatch = true
+		}
+	`
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_ssl_certificate_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_ssl_certificate_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "port", "443"),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "expiry_alert_days", "30"),
+					resource.TestCheckResourceAttrSet("site24x7_ssl_certificate_monitor.test", "location_profile_id"),
+					resource.TestCheckResourceAttrSet("site24x7_ssl_certificate_monitor.test", "threshold_profile_id"),
+					checkMonitorAPI("site24x7_ssl_certificate_monitor.test", map[string]interface{}{
+						"cert_fingerprint": nil,
+						"issuer_name":      nil,
+					}),
+				),
+			},
+
+			resource.TestStep{
+				Config: config2,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_ssl_certificate_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "port", "8443"),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "protocol_versions.#", "2"),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "fingerprint", "ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789"),
+					checkMonitorAPI("site24x7_ssl_certificate_monitor.test", map[string]interface{}{
+						"cert_fingerprint": "ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789ABCDEF0123456789",
+						"issuer_name":      "Let's Encrypt",
+					}),
+				),
+			},
+
+			resource.TestStep{
+				Config:   config2,
+				PlanOnly: true,
+			},
+
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "fingerprint", ""),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "issuer", ""),
+					resource.TestCheckResourceAttr("site24x7_ssl_certificate_monitor.test", "protocol_versions.#", "0"),
+					// the API keeps omitted fields, so removed ones are cleared with a placeholder
+					checkMonitorAPI("site24x7_ssl_certificate_monitor.test", map[string]interface{}{
+						"cert_fingerprint": " ",
+						"issuer_name":      " ",
+					}),
+				),
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_ssl_certificate_monitor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSSLCertificateMonitorValidation(t *testing.T) {
+	const domain = `domain = "sourcegraph.com"`
+	testValidation(t, "site24x7_ssl_certificate_monitor", map[string]validationCase{
+		"domain":            {`domain = "https://www.sourcegraph.com"`, `domain must be`},
+		"port":              {domain + "\n port = 70000", `port must be`},
+		"expiry_alert_days": {domain + "\n expiry_alert_days = 0", `expiry_alert_days must be`},
+		"protocol_versions": {domain + "\n protocol_versions = [\"SSLv3\"]", `protocol_versions\.\d+ must be`},
+		"fingerprint":       {domain + "\n fingerprint = \"AB:CD\"", `fingerprint must be`},
+	})
+}
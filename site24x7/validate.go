@@ -0,0 +1,193 @@
+package site24x7
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// validateStringInSlice returns a validation function accepting only the given values.
+func validateStringInSlice(valid ...string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errs []error) {
+		s := v.(string)
+		for _, ok := range valid {
+			if s == ok {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", k, strings.Join(valid, ", "), s))
+		return
+	}
+}
+
+// validateIntInSlice returns a validation function accepting only the given values.
+func validateIntInSlice(valid ...int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errs []error) {
+		i := v.(int)
+		strs := make([]string, len(valid))
+		for n, ok := range valid {
+			if i == ok {
+				return
+			}
+			strs[n] = strconv.Itoa(ok)
+		}
+		errs = append(errs, fmt.Errorf("%s must be one of %s, got %d", k, strings.Join(strs, ", "), i))
+		return
+	}
+}
+
+// validateIntBetween returns a validation function accepting values from min to max inclusive.
+func validateIntBetween(min, max int) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) (ws []string, errs []error) {
+		i := v.(int)
+		if i < min || i > max {
+			errs = append(errs, fmt.Errorf("%s must be between %d and %d, got %d", k, min, max, i))
+		}
+		return
+	}
+}
+
+// validateNotEmpty rejects empty strings.
+func validateNotEmpty(v interface{}, k string) (ws []string, errs []error) {
+	if strings.TrimSpace(v.(string)) == "" {
+		errs = append(errs, fmt.Errorf("%s must not be empty", k))
+	}
+	return
+}
+
+// validateHTTPURL accepts absolute http and https URLs.
+func validateHTTPURL(v interface{}, k string) (ws []string, errs []error) {
+	u, err := url.Parse(v.(string))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, fmt.Errorf("%s must be an http or https URL, got %q", k, v))
+	}
+	return
+}
+
+// httpMethods maps the HTTP method names accepted as aliases to their API codes.
+var httpMethods = map[string]string{
+	"GET":    "G",
+	"POST":   "P",
+	"HEAD":   "H",
+	"PUT":    "U",
+	"DELETE": "D",
+	"PATCH":  "A",
+}
+
+// normalizeHTTPMethod is a StateFunc storing the API code of an HTTP method given by name or code.
+func normalizeHTTPMethod(v interface{}) string {
+	s := strings.ToUpper(v.(string))
+	if code, ok := httpMethods[s]; ok {
+		return code
+	}
+	return s
+}
+
+// validateHTTPMethod accepts HTTP method names and API codes, in any case.
+func validateHTTPMethod(v interface{}, k string) (ws []string, errs []error) {
+	code := normalizeHTTPMethod(v)
+	for _, c := range httpMethods {
+		if code == c {
+			return
+		}
+	}
+
+	valid := make([]string, 0, 2*len(httpMethods))
+	for name, code := range httpMethods {
+		valid = append(valid, name, code)
+	}
+	sort.Strings(valid)
+	errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", k, strings.Join(valid, ", "), v))
+	return
+}
+
+// checkFrequencies are the check intervals in minutes supported by the API.
+var checkFrequencies = []int{1, 5, 10, 15, 20, 30, 60, 120, 180, 360, 720, 1440}
+
+// validateJSON accepts valid JSON documents.
+func validateJSON(v interface{}, k string) (ws []string, errs []error) {
+	if !json.Valid([]byte(v.(string))) {
+		errs = append(errs, fmt.Errorf("%s must be valid JSON", k))
+	}
+	return
+}
+
+var statusCodeRangeRegexp = regexp.MustCompile(`^[1-5][0-9][0-9](-[1-5][0-9][0-9])?$`)
+
+// validateStatusCodeRange accepts HTTP status codes (e.g. 200) and ranges of them (e.g. 200-299).
+func validateStatusCodeRange(v interface{}, k string) (ws []string, errs []error) {
+	s := v.(string)
+	if !statusCodeRangeRegexp.MatchString(s) {
+		errs = append(errs, fmt.Errorf("%s must be an HTTP status code or a range like 200-299, got %q", k, s))
+		return
+	}
+	if r := strings.SplitN(s, "-", 2); len(r) == 2 && r[0] > r[1] {
+		errs = append(errs, fmt.Errorf("%s must be an ascending range, got %q", k, s))
+	}
+	return
+}
+
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateIdentifier accepts names made of letters, digits and underscores, not starting with a digit.
+func validateIdentifier(v interface{}, k string) (ws []string, errs []error) {
+	if !identifierRegexp.MatchString(v.(string)) {
+		errs = append(errs, fmt.Errorf("%s must consist of letters, digits and underscores, got %q", k, v))
+	}
+	return
+}
+
+var hostnameRegexp = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*\.?$`)
+
+// validateHostname accepts DNS host names, without a scheme or port.
+func validateHostname(v interface{}, k string) (ws []string, errs []error) {
+	s := v.(string)
+	if len(s) > 253 || !hostnameRegexp.MatchString(s) {
+		errs = append(errs, fmt.Errorf("%s must be a host name like www.example.com, got %q", k, s))
+	}
+	return
+}
+
+// normalizeFingerprint is a StateFunc storing certificate fingerprints as upper case hex without separators.
+func normalizeFingerprint(v interface{}) string {
+	return strings.ToUpper(strings.NewReplacer(":", "", " ", "").Replace(v.(string)))
+}
+
+var sha256FingerprintRegexp = regexp.MustCompile(`^[0-9A-F]{64}$`)
+
+// validateFingerprint accepts SHA-256 fingerprints in hex, optionally separated by colons.
+func validateFingerprint(v interface{}, k string) (ws []string, errs []error) {
+	if !sha256FingerprintRegexp.MatchString(normalizeFingerprint(v)) {
+		errs = append(errs, fmt.Errorf("%s must be a SHA-256 fingerprint in hex, got %q", k, v))
+	}
+	return
+}
+
+// validateHost accepts host names and IP addresses.
+func validateHost(v interface{}, k string) (ws []string, errs []error) {
+	if net.ParseIP(v.(string)) != nil {
+		return
+	}
+	if _, errs := validateHostname(v, k); len(errs) > 0 {
+		return nil, []error{fmt.Errorf("%s must be a host name or IP address, got %q", k, v)}
+	}
+	return
+}
+
+var domainNameRegexp = regexp.MustCompile(`^(?i)[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?(\.[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?)*\.?$`)
+
+// validateDomainName accepts DNS names, which unlike host names may contain underscores, e.g. _sip._tcp.example.com.
+func validateDomainName(v interface{}, k string) (ws []string, errs []error) {
+	s := v.(string)
+	if len(s) > 253 || !domainNameRegexp.MatchString(s) {
+		errs = append(errs, fmt.Errorf("%s must be a domain name like example.com, got %q", k, s))
+	}
+	return
+}
@@ -0,0 +1,104 @@
+package site24x7
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateHTTPMethod(t *testing.T) {
+	for _, v := range []string{"G", "p", "GET", "post", "Delete", "A"} {
+		if _, errs := validateHTTPMethod(v, "http_method"); len(errs) != 0 {
+			t.Errorf("%q: got errors %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "X", "FETCH"} {
+		if _, errs := validateHTTPMethod(v, "http_method"); len(errs) == 0 {
+			t.Errorf("%q: got no errors", v)
+		}
+	}
+
+	if got := normalizeHTTPMethod("get"); got != "G" {
+		t.Errorf("got %q for get, want G", got)
+	}
+	if got := normalizeHTTPMethod("h"); got != "H" {
+		t.Errorf("got %q for h, want H", got)
+	}
+}
+
+func TestValidateHTTPURL(t *testing.T) {
+	for v, valid := range map[string]bool{
+		"https://www.sourcegraph.com":   true,
+		"http://localhost:8080/healthz": true,
+		"www.sourcegraph.com":           false,
+		"ftp://www.sourcegraph.com":     false,
+		"https://":                      false,
+	} {
+		if _, errs := validateHTTPURL(v, "website"); (len(errs) == 0) != valid {
+			t.Errorf("%q: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	for v, valid := range map[string]bool{
+		"sourcegraph.com":             true,
+		"www.sourcegraph.com.":        true,
+		"xn--bcher-kva.example":       true,
+		"https://www.sourcegraph.com": false,
+		"sourcegraph.com:443":         false,
+		"-sourcegraph.com":            false,
+		"":                            false,
+	} {
+		if _, errs := validateHostname(v, "domain"); (len(errs) == 0) != valid {
+			t.Errorf("%q: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	for v, valid := range map[string]bool{
+		"ns1.example.com":      true,
+		"8.8.8.8":              true,
+		"2001:4860:4860::8888": true,
+		"dns://8.8.8.8":        false,
+		"8.8.8.8:53":           false,
+	} {
+		if _, errs := validateHost(v, "dns_host"); (len(errs) == 0) != valid {
+			t.Errorf("%q: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
+}
+
+func TestValidateDomainName(t *testing.T) {
+	for v, valid := range map[string]bool{
+		"sourcegraph.com":           true,
+		"_sip._tcp.sourcegraph.com": true,
+		"_dmarc.sourcegraph.com.":   true,
+		"https://sourcegraph.com":   false,
+		"-sourcegraph.com":          false,
+		"":                          false,
+	} {
+		if _, errs := validateDomainName(v, "domain"); (len(errs) == 0) != valid {
+			t.Errorf("%q: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
+}
+
+func TestValidateFingerprint(t *testing.T) {
+	const fingerprint = "AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89:AB:CD:EF:01:23:45:67:89"
+	for v, valid := range map[string]bool{
+		fingerprint:                               true,
+		strings.ToLower(fingerprint):              true,
+		strings.Replace(fingerprint, ":", "", -1): true,
+		fingerprint[:len(fingerprint)-3]:          false,
+		"not a fingerprint":                       false,
+	} {
+		if _, errs := validateFingerprint(v, "fingerprint"); (len(errs) == 0) != valid {
+			t.Errorf("%q: got errors %v, want valid %v", v, errs, valid)
+		}
+	}
+
+	if got, want := normalizeFingerprint(strings.ToLower(fingerprint)), strings.Replace(fingerprint, ":", "", -1); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
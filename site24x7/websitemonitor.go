@@ -1,15 +1,12 @@
 package site24x7
 
 import (
-	"bytes"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
 )
 
 func resourceSite24x7WebsiteMonitor() *schema.Resource {
@@ -18,29 +15,46 @@ func resourceSite24x7WebsiteMonitor() *schema.Resource {
 		Read:   websiteMonitorRead,
 		Update: websiteMonitorUpdate,
 		Delete: websiteMonitorDelete,
-		Exists: websiteMonitorExists,
 
-		Schema: map[string]*schema.Schema{
-			"display_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
+		Importer: &schema.ResourceImporter{
+			State: importMonitor("URL"),
+		},
 
-			"website": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+		CustomizeDiff: validateContentChecks,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultWriteTimeout),
+			Update: schema.DefaultTimeout(defaultWriteTimeout),
+		},
+
+		SchemaVersion: 2,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceSite24x7WebsiteMonitorV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: websiteMonitorStateUpgradeV0,
+			},
+			{
+				Version: 1,
+				Type:    resourceSite24x7WebsiteMonitorV1().CoreConfigSchema().ImpliedType(),
+				Upgrade: websiteMonitorStateUpgradeV1,
 			},
+		},
 
-			"check_frequency": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  1,
+		Schema: monitorSchema(map[string]*schema.Schema{
+			"website": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateHTTPURL,
+				DiffSuppressFunc: suppressEquivalentURL,
 			},
 
 			"http_method": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "G",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "G",
+				ValidateFunc: validateHTTPMethod,
+				StateFunc:    normalizeHTTPMethod,
 			},
 
 			"auth_user": &schema.Schema{
@@ -49,43 +63,46 @@ func resourceSite24x7WebsiteMonitor() *schema.Resource {
 			},
 
 			"auth_pass": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-
-			"matching_keyword_value": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"matching_keyword_severity": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  2,
-			},
-
-			"unmatching_keyword_value": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"unmatching_keyword_severity": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  2,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressHashedSecret("hash_auth_pass"),
 			},
 
-			"match_regex_value": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"match_regex_severity": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  2,
+			"hash_auth_pass": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Store only a SHA-256 hash of auth_pass in the state.",
 			},
 
-			"match_case": &schema.Schema{
-				Type:     schema.TypeBool,
+			"content_check": &schema.Schema{
+				Type:     schema.TypeSet,
 				Optional: true,
+				MaxItems: len(contentCheckTypes),
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateStringInSlice(contentCheckTypes...),
+						},
+						"value": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateNotEmpty,
+						},
+						"severity": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      api.Trouble.String(),
+							ValidateFunc: validateStringInSlice(api.Down.String(), api.Trouble.String()),
+						},
+						"case_sensitive": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
 			},
 
 			"user_agent": &schema.Schema{
@@ -99,57 +116,10 @@ func resourceSite24x7WebsiteMonitor() *schema.Resource {
 			},
 
 			"timeout": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  10,
-			},
-
-			"location_profile_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-			},
-
-			"notification_profile_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-
-			"threshold_profile_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-
-			"monitor_groups": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
-
-			"user_group_ids": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Computed: true,
-			},
-
-			"action_ids": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
-			},
-
-			"action_alert_types": &schema.Schema{
-				Type: schema.TypeList,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validateIntBetween(1, 45),
 			},
 
 			"use_name_server": &schema.Schema{
@@ -157,382 +127,193 @@ func resourceSite24x7WebsiteMonitor() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
-		},
+		}),
 	}
 }
 
-type Status int
-
-const (
-	Down           Status = 0
-	Up             Status = 1
-	Trouble        Status = 2
-	Suspended      Status = 5
-	Maintenance    Status = 7
-	Discovery      Status = 9
-	DiscoveryError Status = 10
-)
-
-type ValueAndSeverity struct {
-	Value    string `json:"value"`
-	Severity Status `json:"severity"`
-}
-
-type Header struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
-}
-
-type ActionRef struct {
-	ActionID  string `json:"action_id"`
-	AlertType Status `json:"alert_type"`
-}
-
-type WebsiteMonitor struct {
-	MonitorID             string           `json:"monitor_id,omitempty"`
-	DisplayName           string           `json:"display_name"`
-	Type                  string           `json:"type"`
-	Website               string           `json:"website"`
-	CheckFrequency        string           `json:"check_frequency"`
-	HTTPMethod            string           `json:"http_method"`
-	AuthUser              string           `json:"auth_user"`
-	AuthPass              string           `json:"auth_pass"`
-	MatchingKeyword       ValueAndSeverity `json:"matching_keyword"`
-	UnmatchingKeyword     ValueAndSeverity `json:"unmatching_keyword"`
-	MatchRegex            ValueAndSeverity `json:"match_regex"`
-	MatchCase             bool             `json:"match_case"`
-	UserAgent             string           `json:"user_agent"`
-	CustomHeaders         []Header         `json:"custom_headers"`
-	Timeout               int              `json:"timeout"`
-	LocationProfileID     string           `json:"location_profile_id"`
-	NotificationProfileID string           `json:"notification_profile_id"`
-	ThresholdProfileID    string           `json:"threshold_profile_id"`
-	MonitorGroups         []string         `json:"monitor_groups,omitempty"`
-	UserGroupIDs          []string         `json:"user_group_ids"`
-	ActionIDs             []ActionRef      `json:"action_ids,omitempty"`
-	UseNameServer         bool             `json:"use_name_server"`
-}
-
 func websiteMonitorCreate(d *schema.ResourceData, meta interface{}) error {
-	return websiteMonitorCreateOrUpdate(http.MethodPost, "https://www.site24x7.com/api/monitors", http.StatusCreated, d, meta)
+	return websiteMonitorCreateOrUpdate(d, meta)
 }
 
 func websiteMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
-	return websiteMonitorCreateOrUpdate(http.MethodPut, "https://www.site24x7.com/api/monitors/"+d.Id(), http.StatusOK, d, meta)
+	return websiteMonitorCreateOrUpdate(d, meta)
 }
 
-func websiteMonitorCreateOrUpdate(method, url string, expectedResponseStatus int, d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*http.Client)
+func websiteMonitorCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*providerMeta).client
 
-	customHeaders := []Header{}
-	for k, v := range d.Get("custom_headers").(map[string]interface{}) {
-		customHeaders = append(customHeaders, Header{Name: k, Value: v.(string)})
+	m := websiteMonitorFromResourceData(d)
+
+	err := meta.(*providerMeta).defaults.setDefaultProfiles(d, "URL", &m.LocationProfileID, &m.NotificationProfileID, &m.ThresholdProfileID, &m.UserGroupIDs)
+	if err != nil {
+		return err
 	}
 
-	var monitorGroups []string
-	for _, id := range d.Get("monitor_groups").([]interface{}) {
-		monitorGroups = append(monitorGroups, id.(string))
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.Id() == "" {
+		id, err := client.CreateWebsiteMonitor(m)
+		if err != nil {
+			return fmt.Errorf("error creating website monitor %q: %w", m.DisplayName, err)
+		}
+		d.SetId(id)
+		timeout = d.Timeout(schema.TimeoutCreate)
+	} else {
+		clearRemovedContentChecks(d, m)
+		if err := client.UpdateWebsiteMonitor(d.Id(), m); err != nil {
+			return fmt.Errorf("error updating website monitor %s: %w", d.Id(), err)
+		}
 	}
 
-	var userGroupIDs []string
-	for _, id := range d.Get("user_group_ids").([]interface{}) {
-		userGroupIDs = append(userGroupIDs, id.(string))
+	if d.Get("hash_auth_pass").(bool) {
+		d.Set("auth_pass", hashSecret(d.Get("auth_pass").(string)))
 	}
 
-	actionIDs := d.Get("action_ids").([]interface{})
-	actionAlertTypes := d.Get("action_alert_types").([]interface{})
-	actionRefs := make([]ActionRef, len(actionIDs))
-	for i := range actionRefs {
-		alertType := Status(-1)
-		if i < len(actionAlertTypes) {
-			alertType = Status(actionAlertTypes[i].(int))
+	return readAfterWrite(d, "website monitor", timeout, func() error {
+		m, err := client.GetWebsiteMonitor(d.Id())
+		if err != nil {
+			return err
 		}
-		actionRefs[i] = ActionRef{ActionID: actionIDs[i].(string), AlertType: alertType}
-	}
+		updateWebsiteMonitorResourceData(d, m)
+		return nil
+	})
+}
 
-	m := &WebsiteMonitor{
-		DisplayName:    d.Get("display_name").(string),
-		Type:           "URL",
-		Website:        d.Get("website").(string),
-		CheckFrequency: strconv.Itoa(d.Get("check_frequency").(int)),
-		HTTPMethod:     d.Get("http_method").(string),
-		AuthUser:       d.Get("auth_user").(string),
-		AuthPass:       d.Get("auth_pass").(string),
-		MatchingKeyword: ValueAndSeverity{
-			Value:    fixEmpty(d.Get("matching_keyword_value").(string)),
-			Severity: Status(d.Get("matching_keyword_severity").(int)),
-		},
-		UnmatchingKeyword: ValueAndSeverity{
-			Value:    fixEmpty(d.Get("unmatching_keyword_value").(string)),
-			Severity: Status(d.Get("unmatching_keyword_severity").(int)),
-		},
-		MatchRegex: ValueAndSeverity{
-			Value:    fixEmpty(d.Get("match_regex_value").(string)),
-			Severity: Status(d.Get("match_regex_severity").(int)),
-		},
-		MatchCase:             d.Get("match_case").(bool),
+// websiteMonitorFromResourceData builds the API representation of the monitor configured in d.
+func websiteMonitorFromResourceData(d *schema.ResourceData) *api.WebsiteMonitor {
+	m := &api.WebsiteMonitor{
+		DisplayName:           d.Get("display_name").(string),
+		Type:                  "URL",
+		Website:               d.Get("website").(string),
+		CheckFrequency:        strconv.Itoa(d.Get("check_frequency").(int)),
+		HTTPMethod:            normalizeHTTPMethod(d.Get("http_method")),
+		AuthUser:              d.Get("auth_user").(string),
 		UserAgent:             d.Get("user_agent").(string),
-		CustomHeaders:         customHeaders,
+		CustomHeaders:         customHeaders(d.Get("custom_headers")),
 		Timeout:               d.Get("timeout").(int),
 		LocationProfileID:     d.Get("location_profile_id").(string),
 		NotificationProfileID: d.Get("notification_profile_id").(string),
 		ThresholdProfileID:    d.Get("threshold_profile_id").(string),
-		MonitorGroups:         monitorGroups,
-		UserGroupIDs:          userGroupIDs,
-		ActionIDs:             actionRefs,
+		MonitorGroups:         stringList(d.Get("monitor_groups")),
+		UserGroupIDs:          stringList(d.Get("user_group_ids")),
+		ActionIDs:             actionRefsFromResourceData(d),
 		UseNameServer:         d.Get("use_name_server").(bool),
 	}
 
-	if m.LocationProfileID == "" {
-		id, err := defaultLocationProfile(client)
-		if err != nil {
-			return err
-		}
-		m.LocationProfileID = id
-		d.Set("location_profile_id", id)
+	if authPass, ok := writeOnlySecret(d, "auth_pass"); ok {
+		m.AuthPass = &authPass
 	}
-	if m.NotificationProfileID == "" {
-		id, err := defaultNotificationProfile(client)
-		if err != nil {
-			return err
-		}
-		m.NotificationProfileID = id
-		d.Set("notification_profile_id", id)
-	}
-	if m.ThresholdProfileID == "" {
-		id, err := defaultThresholdProfile(client, "URL")
-		if err != nil {
-			return err
+
+	for _, v := range d.Get("content_check").(*schema.Set).List() {
+		check := v.(map[string]interface{})
+		// the severity was validated
+		severity, _ := api.ParseStatus(check["severity"].(string))
+		*contentCheckField(m, check["type"].(string)) = &api.ValueAndSeverity{
+			Value:    check["value"].(string),
+			Severity: severity,
 		}
-		m.ThresholdProfileID = id
-		d.Set("threshold_profile_id", id)
-	}
-	if len(m.UserGroupIDs) == 0 {
-		id, err := defaultUserGroup(client)
-		if err != nil {
-			return err
+		if check["case_sensitive"].(bool) {
+			m.MatchCase = true
 		}
-		m.UserGroupIDs = []string{id}
-		d.Set("user_group_ids", []string{id})
-	}
-
-	body, err := json.Marshal(m)
-	if err != nil {
-		return err
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
+	return m
+}
 
-	if resp.StatusCode != expectedResponseStatus {
-		return parseAPIError(resp.Body)
+// contentCheckTypes are the types of content_check blocks, each mapping to a field of api.WebsiteMonitor.
+var contentCheckTypes = []string{"keyword", "not_keyword", "regex"}
+
+// contentCheckField returns the field of m holding the content check of the given type.
+func contentCheckField(m *api.WebsiteMonitor, typ string) **api.ValueAndSeverity {
+	switch typ {
+	case "keyword":
+		return &m.MatchingKeyword
+	case "not_keyword":
+		return &m.UnmatchingKeyword
+	case "regex":
+		return &m.MatchRegex
+	default:
+		panic("unknown content check type " + typ)
 	}
+}
 
-	var apiResp struct {
-		Data struct {
-			MonitorID string `json:"monitor_id"`
-		} `json:"data"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return err
+// clearRemovedContentChecks adds the content checks removed from the configuration to m with an empty value, as
+// the API keeps checks that are omitted from an update.
+func clearRemovedContentChecks(d *schema.ResourceData, m *api.WebsiteMonitor) {
+	old, _ := d.GetChange("content_check")
+	for _, v := range old.(*schema.Set).List() {
+		f := contentCheckField(m, v.(map[string]interface{})["type"].(string))
+		if *f == nil {
+			*f = &api.ValueAndSeverity{Value: fixEmpty(""), Severity: api.Trouble}
+		}
 	}
-	d.SetId(apiResp.Data.MonitorID)
-	// can't update the rest of the data here, because the response format is broken
-
-	return nil
 }
 
-func fixEmpty(s string) string {
-	if s == "" {
-		return " "
+// validateContentChecks checks the constraints between content_check blocks, which the API stores in single
+// fields.
+func validateContentChecks(d *schema.ResourceDiff, meta interface{}) error {
+	checks := d.Get("content_check").(*schema.Set).List()
+	seen := make(map[string]bool)
+	for i, v := range checks {
+		check := v.(map[string]interface{})
+		typ := check["type"].(string)
+		if seen[typ] {
+			return fmt.Errorf("content_check: only one check of type %q is allowed", typ)
+		}
+		seen[typ] = true
+		if i > 0 && check["case_sensitive"] != checks[0].(map[string]interface{})["case_sensitive"] {
+			return errors.New("content_check: case_sensitive must be the same for all checks")
+		}
 	}
-	return s
+	return nil
 }
 
 func websiteMonitorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*http.Client)
+	client := meta.(*providerMeta).client
 
-	var apiResp struct {
-		Data WebsiteMonitor `json:"data"`
-	}
-	if err := doGetRequest(client, "https://www.site24x7.com/api/monitors/"+d.Id(), &apiResp); err != nil {
-		return err
+	m, err := client.GetWebsiteMonitor(d.Id())
+	if err != nil {
+		return handleReadError(d, "website monitor", err)
 	}
-	updateWebsiteMonitorResourceData(d, &apiResp.Data)
+	updateWebsiteMonitorResourceData(d, m)
 
 	return nil
 }
 
-func updateWebsiteMonitorResourceData(d *schema.ResourceData, m *WebsiteMonitor) {
+func updateWebsiteMonitorResourceData(d *schema.ResourceData, m *api.WebsiteMonitor) {
 	d.Set("display_name", m.DisplayName)
 	d.Set("website", m.Website)
-	d.Set("check_frequency", m.CheckFrequency)
+	if checkFrequency, err := strconv.Atoi(m.CheckFrequency); err == nil {
+		d.Set("check_frequency", checkFrequency)
+	}
 	d.Set("timeout", m.Timeout)
 	d.Set("http_method", m.HTTPMethod)
 	d.Set("auth_user", m.AuthUser)
-	d.Set("auth_pass", m.AuthPass)
-	d.Set("matching_keyword_value", m.MatchingKeyword.Value)
-	d.Set("matching_keyword_severity", int(m.MatchingKeyword.Severity))
-	d.Set("unmatching_keyword_value", m.UnmatchingKeyword.Value)
-	d.Set("unmatching_keyword_severity", int(m.UnmatchingKeyword.Severity))
-	d.Set("match_regex_value", m.MatchRegex.Value)
-	d.Set("match_regex_severity", int(m.MatchRegex.Severity))
-	d.Set("match_case", m.MatchCase)
-	d.Set("user_agent", m.UserAgent)
-	customHeaders := make(map[string]interface{})
-	for _, h := range m.CustomHeaders {
-		if h.Name == "" {
+	// auth_pass is write-only: the API echoes it, but the state keeps the configured value or its hash
+	var contentChecks []interface{}
+	for _, typ := range contentCheckTypes {
+		check := *contentCheckField(m, typ)
+		if check == nil || unfixEmpty(check.Value) == "" {
 			continue
 		}
-		customHeaders[h.Name] = h.Value
-	}
-	d.Set("custom_headers", customHeaders)
+		contentChecks = append(contentChecks, map[string]interface{}{
+			"type":           typ,
+			"value":          check.Value,
+			"severity":       check.Severity.String(),
+			"case_sensitive": m.MatchCase,
+		})
+	}
+	d.Set("content_check", contentChecks)
+	d.Set("user_agent", m.UserAgent)
+	d.Set("custom_headers", flattenCustomHeaders(m.CustomHeaders))
 	d.Set("location_profile_id", m.LocationProfileID)
 	d.Set("notification_profile_id", m.NotificationProfileID)
 	d.Set("threshold_profile_id", m.ThresholdProfileID)
 	d.Set("monitor_groups", m.MonitorGroups)
 	d.Set("user_group_ids", m.UserGroupIDs)
-	actionIDs := make([]string, len(m.ActionIDs))
-	actionAlertTypes := make([]Status, len(m.ActionIDs))
-	for i, r := range m.ActionIDs {
-		actionIDs[i] = r.ActionID
-		actionAlertTypes[i] = r.AlertType
-	}
-	d.Set("action_ids", actionIDs)
-	d.Set("action_alert_types", actionAlertTypes)
+	d.Set("action", flattenActionRefs(m.ActionIDs))
 	d.Set("use_name_server", m.UseNameServer)
 }
 
 func websiteMonitorDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*http.Client)
-
-	req, err := http.NewRequest(http.MethodDelete, "https://www.site24x7.com/api/monitors/"+d.Id(), nil)
-	if err != nil {
-		return err
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return parseAPIError(resp.Body)
-	}
-
-	return nil
-}
-
-func websiteMonitorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	return fetchWebsiteMonitorExists(meta.(*http.Client), d.Id())
-}
-
-func fetchWebsiteMonitorExists(client *http.Client, id string) (bool, error) {
-	resp, err := client.Get("https://www.site24x7.com/api/monitors/" + id)
-	if err != nil {
-		return false, err
-	}
-	defer resp.Body.Close()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		return true, nil
-	case http.StatusNotFound:
-		return false, nil
-	default:
-		return false, parseAPIError(resp.Body)
-	}
-}
-
-func defaultLocationProfile(client *http.Client) (string, error) {
-	var apiResp struct {
-		Data []struct {
-			ProfileID string `json:"profile_id"`
-		} `json:"data"`
-	}
-	if err := doGetRequest(client, "https://www.site24x7.com/api/location_profiles", &apiResp); err != nil {
-		return "", err
-	}
-	return apiResp.Data[0].ProfileID, nil
-}
-
-func defaultNotificationProfile(client *http.Client) (string, error) {
-	var apiResp struct {
-		Data []struct {
-			ProfileID string `json:"profile_id"`
-		} `json:"data"`
-	}
-	if err := doGetRequest(client, "https://www.site24x7.com/api/notification_profiles", &apiResp); err != nil {
-		return "", err
-	}
-	return apiResp.Data[0].ProfileID, nil
-}
-
-func defaultThresholdProfile(client *http.Client, monitorType string) (string, error) {
-	var apiResp struct {
-		Data []struct {
-			ProfileID   string `json:"profile_id"`
-			MonitorType string `json:"type"`
-		} `json:"data"`
-	}
-	if err := doGetRequest(client, "https://www.site24x7.com/api/threshold_profiles", &apiResp); err != nil {
-		return "", err
-	}
-	for _, p := range apiResp.Data {
-		if p.MonitorType == monitorType {
-			return p.ProfileID, nil
-		}
-	}
-	return "", errors.New("no threshold profile found")
-}
-
-func defaultUserGroup(client *http.Client) (string, error) {
-	var apiResp struct {
-		Data []struct {
-			UserGroupID string `json:"user_group_id"`
-		} `json:"data"`
-	}
-	if err := doGetRequest(client, "https://www.site24x7.com/api/user_groups", &apiResp); err != nil {
-		return "", err
-	}
-	return apiResp.Data[0].UserGroupID, nil
-}
-
-func doGetRequest(client *http.Client, url string, data interface{}) error {
-	resp, err := client.Get(url)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return parseAPIError(resp.Body)
-	}
-
-	return json.NewDecoder(resp.Body).Decode(data)
-}
-
-func parseAPIError(r io.Reader) error {
-	var apiErr struct {
-		ErrorCode int             `json:"error_code"`
-		Message   string          `json:"message"`
-		ErrorInfo json.RawMessage `json:"error_info"`
-	}
-	if err := json.NewDecoder(r).Decode(&apiErr); err != nil {
-		return err
-	}
-	if len(apiErr.ErrorInfo) != 0 {
-		return fmt.Errorf("%s (%s)", apiErr.Message, string(apiErr.ErrorInfo))
-	}
-	return fmt.Errorf("%s", apiErr.Message)
+	return handleDeleteError(d, "website monitor", meta.(*providerMeta).client.DeleteMonitor(d.Id()))
 }
@@ -0,0 +1,126 @@
+package site24x7
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
+)
+
+// resourceSite24x7WebsiteMonitorV0 is the schema of site24x7_website_monitor before version 1, needed to decode
+// old states. Only the attribute types matter.
+func resourceSite24x7WebsiteMonitorV0() *schema.Resource {
+	optionalString := &schema.Schema{Type: schema.TypeString, Optional: true}
+	optionalInt := &schema.Schema{Type: schema.TypeInt, Optional: true}
+	optionalBool := &schema.Schema{Type: schema.TypeBool, Optional: true}
+	computedString := &schema.Schema{Type: schema.TypeString, Optional: true, Computed: true}
+	stringList := &schema.Schema{Type: schema.TypeList, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}}
+
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"display_name":                optionalString,
+			"website":                     optionalString,
+			"check_frequency":             optionalInt,
+			"http_method":                 optionalString,
+			"auth_user":                   optionalString,
+			"auth_pass":                   optionalString,
+			"matching_keyword_value":      optionalString,
+			"matching_keyword_severity":   optionalInt,
+			"unmatching_keyword_value":    optionalString,
+			"unmatching_keyword_severity": optionalInt,
+			"match_regex_value":           optionalString,
+			"match_regex_severity":        optionalInt,
+			"match_case":                  optionalBool,
+			"user_agent":                  optionalString,
+			"custom_headers":              &schema.Schema{Type: schema.TypeMap, Optional: true},
+			"timeout":                     optionalInt,
+			"location_profile_id":         computedString,
+			"notification_profile_id":     computedString,
+			"threshold_profile_id":        computedString,
+			"monitor_groups":              stringList,
+			"user_group_ids":              &schema.Schema{Type: schema.TypeList, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+			"action_ids":                  stringList,
+			"action_alert_types":          stringList,
+			"use_name_server":             optionalBool,
+		},
+	}
+}
+
+// resourceSite24x7WebsiteMonitorV1 is the schema of site24x7_website_monitor version 1, which replaced action_ids
+// and action_alert_types with action blocks.
+func resourceSite24x7WebsiteMonitorV1() *schema.Resource {
+	r := resourceSite24x7WebsiteMonitorV0()
+	delete(r.Schema, "action_ids")
+	delete(r.Schema, "action_alert_types")
+	r.Schema["action"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id":         &schema.Schema{Type: schema.TypeString, Optional: true},
+				"alert_type": &schema.Schema{Type: schema.TypeString, Optional: true},
+			},
+		},
+	}
+	return r
+}
+
+// websiteMonitorStateUpgradeV0 replaces the parallel action_ids and action_alert_types lists with action blocks.
+// Alert types stored as numbers are converted to their names, an alert type that was missing or invalid is left
+// empty so that the next plan shows it.
+func websiteMonitorStateUpgradeV0(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	ids, _ := rawState["action_ids"].([]interface{})
+	alertTypes, _ := rawState["action_alert_types"].([]interface{})
+
+	actions := make([]interface{}, 0, len(ids))
+	for i, id := range ids {
+		alertType := ""
+		if i < len(alertTypes) {
+			if s, ok := alertTypes[i].(string); ok {
+				if n, err := strconv.Atoi(s); err == nil {
+					alertType = api.Status(n).String()
+				}
+			}
+		}
+		actions = append(actions, map[string]interface{}{
+			"id":         id,
+			"alert_type": alertType,
+		})
+	}
+
+	delete(rawState, "action_ids")
+	delete(rawState, "action_alert_types")
+	rawState["action"] = actions
+	return rawState, nil
+}
+
+// websiteMonitorStateUpgradeV1 replaces the flat keyword and regex attributes with content_check blocks.
+func websiteMonitorStateUpgradeV1(rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	matchCase, _ := rawState["match_case"].(bool)
+
+	checks := []interface{}{}
+	for typ, prefix := range map[string]string{
+		"keyword":     "matching_keyword",
+		"not_keyword": "unmatching_keyword",
+		"regex":       "match_regex",
+	} {
+		value, _ := rawState[prefix+"_value"].(string)
+		severity, _ := rawState[prefix+"_severity"].(float64)
+		delete(rawState, prefix+"_value")
+		delete(rawState, prefix+"_severity")
+
+		if unfixEmpty(value) == "" {
+			continue
+		}
+		checks = append(checks, map[string]interface{}{
+			"type":           typ,
+			"value":          value,
+			"severity":       api.Status(severity).String(),
+			"case_sensitive": matchCase,
+		})
+	}
+
+	delete(rawState, "match_case")
+	rawState["content_check"] = checks
+	return rawState, nil
+}
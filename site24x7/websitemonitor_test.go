@@ -2,11 +2,14 @@ package site24x7
 
 import (
 	"fmt"
-	"net/http"
+	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/sourcegraph/terraform-provider-site24x7/site24x7/api"
 )
 
 func TestWebsiteMonitor(t *testing.T) {
@@ -21,53 +24,436 @@ func TestWebsiteMonitor(t *testing.T) {
 		resource "site24x7_website_monitor" "test" {
 			display_name = "new name"
 			website = "https://www.sourcegraph.com/login"
-			custom_headers { "foo" = "bar" }
+			custom_headers = { "foo" = "bar" }
 		}
 	`
 
-	resource.Test(t, resource.TestCase{
+	testAccTest(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
 		Providers:    testAccProviders,
-		CheckDestroy: checkWebsiteMonitorDestroyed,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_website_monitor"),
 		Steps: []resource.TestStep{
 			resource.TestStep{
 				Config: config1,
 				Check: resource.ComposeTestCheckFunc(
-					checkWebsiteMonitorExists,
+					checkMonitorExists("site24x7_website_monitor.test"),
 				),
 			},
 
 			resource.TestStep{
+				// the provider has to renew its access token to update the monitor
+				PreConfig: func() {
+					if testFakeServer != nil {
+						testFakeServer.ExpireTokens()
+					}
+				},
 				Config: config2,
 				Check: resource.ComposeTestCheckFunc(
-					checkWebsiteMonitorExists,
+					checkMonitorExists("site24x7_website_monitor.test"),
 					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "display_name", "new name"),
 				),
 			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_website_monitor.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+
+			resource.TestStep{
+				ResourceName:      "site24x7_website_monitor.test",
+				ImportState:       true,
+				ImportStateId:     "new name",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestWebsiteMonitorImportWrongType checks that a monitor of another type can't be imported by id.
+func TestWebsiteMonitorImportWrongType(t *testing.T) {
+	if testFakeServer == nil {
+		t.Skip("needs the fake API")
+	}
+	id := testFakeServer.Add("monitors", map[string]interface{}{
+		"display_name": "name server",
+		"type":         "DNS",
+		"dns_host":     "8.8.8.8",
+		"domain_name":  "sourcegraph.com",
+	})
+	defer testFakeServer.Remove("monitors", id)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: `
+					resource "site24x7_website_monitor" "test" {
+						display_name = "name server"
+						website = "https://www.sourcegraph.com"
+					}
+				`,
+				ResourceName:  "site24x7_website_monitor.test",
+				ImportState:   true,
+				ImportStateId: id,
+				ExpectError:   regexp.MustCompile(`monitor \d+ is a DNS monitor, not a URL monitor`),
+			},
 		},
 	})
 }
 
-func checkWebsiteMonitorExists(s *terraform.State) error {
-	rs := s.RootModule().Resources["site24x7_website_monitor.test"]
-	exists, err := fetchWebsiteMonitorExists(testAccProvider.Meta().(*http.Client), rs.Primary.ID)
+// TestWebsiteMonitorConverges checks that the plan after applying, reading back and clearing every kind of
+// attribute is empty.
+func TestWebsiteMonitorConverges(t *testing.T) {
+	const config1 = `
+		resource "site24x7_website_monitor" "test" {
+			display_name = "converges"
+			website = "https://www.sourcegraph.com/"
+			check_frequency = 5
+			http_method = "POST"
+			content_check {
+				type = "keyword"
+				value = "Sourcegraph"
+				severity = "down"
+				case_sensitive = true
+			}
+			content_check {
+				type = "not_keyword"
+				value = "error"
+				case_sensitive = true
+			}
+			content_check {
+				type = "regex"
+				value = "[Ss]earch"
+				case_sensitive = true
+			}
+			user_agent = "terraform"
+			timeout = 30
+			monitor_groups = ["1", "2"]
+			action {
+				id = "10"
+				alert_type = "down"
+			}
+			action {
+				id = "11"
+				alert_type = "trouble"
+			}
+		}
+	`
+
+	const config2 = `
+		resource "site24x7_website_monitor" "test" {
+			display_name = "converges"
+			website = "https://www.sourcegraph.com"
+		}
+	`
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_website_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config1,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_website_monitor.test"),
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "check_frequency", "5"),
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "http_method", "P"),
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "action.1.alert_type", "trouble"),
+				),
+			},
+			resource.TestStep{
+				Config:   config1,
+				PlanOnly: true,
+			},
+			resource.TestStep{
+				Config: config2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "content_check.#", "0"),
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "action.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config:   config2,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestWebsiteMonitorRoundTrip(t *testing.T) {
+	raw := map[string]interface{}{
+		"display_name":    "round trip",
+		"website":         "https://www.sourcegraph.com",
+		"check_frequency": 15,
+		"content_check": []interface{}{
+			map[string]interface{}{"type": "not_keyword", "value": "error", "severity": "down", "case_sensitive": true},
+		},
+		"custom_headers": map[string]interface{}{"foo": "bar"},
+		"action":         []interface{}{map[string]interface{}{"id": "10", "alert_type": "trouble"}},
+	}
+	res := resourceSite24x7WebsiteMonitor()
+	d := schema.TestResourceDataRaw(t, res.Schema, raw)
+
+	m := websiteMonitorFromResourceData(d)
+	if m.CheckFrequency != "15" || m.MatchingKeyword != nil || m.UnmatchingKeyword.Severity != api.Down || !m.MatchCase {
+		t.Errorf("got API monitor %+v", m)
+	}
+
+	read := res.TestResourceData()
+	updateWebsiteMonitorResourceData(read, m)
+	for k := range res.Schema {
+		got, want := read.Get(k), d.Get(k)
+		if set, ok := got.(*schema.Set); ok {
+			got, want = set.List(), want.(*schema.Set).List()
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %#v after round trip, want %#v", k, got, want)
+		}
+	}
+}
+
+func TestWebsiteMonitorDeletedOutsideTerraform(t *testing.T) {
+	const config = `
+		resource "site24x7_website_monitor" "test" {
+			display_name = "deleted"
+			website = "https://www.sourcegraph.com"
+		}
+	`
+
+	var id string
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_website_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id = s.RootModule().Resources["site24x7_website_monitor.test"].Primary.ID
+					return nil
+				},
+			},
+			resource.TestStep{
+				PreConfig: func() {
+					if err := testAccClient().DeleteMonitor(id); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_website_monitor.test"),
+					func(s *terraform.State) error {
+						if s.RootModule().Resources["site24x7_website_monitor.test"].Primary.ID == id {
+							return fmt.Errorf("monitor %s was not recreated", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestWebsiteMonitorHashedAuthPass(t *testing.T) {
+	config := func(name, password string, hash bool) string {
+		return fmt.Sprintf(`
+			resource "site24x7_website_monitor" "test" {
+				display_name = %q
+				website = "https://www.sourcegraph.com"
+				auth_user = "admin"
+				auth_pass = %q
+				hash_auth_pass = %t
+			}
+		`, name, password, hash)
+	}
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_website_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: config("hashed", "hunter2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "auth_pass", hashSecret("hunter2")),
+				),
+			},
+			resource.TestStep{
+				Config:   config("hashed", "hunter2", true),
+				PlanOnly: true,
+			},
+			// updates that don't change the password omit it, so the API keeps the stored one
+			resource.TestStep{
+				Config: config("renamed", "hunter2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "display_name", "renamed"),
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "auth_pass", hashSecret("hunter2")),
+					checkWebsiteMonitorAuthPass("hunter2"),
+				),
+			},
+			resource.TestStep{
+				Config:   config("renamed", "hunter2", true),
+				PlanOnly: true,
+			},
+			resource.TestStep{
+				Config: config("renamed", "correct horse", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "auth_pass", hashSecret("correct horse")),
+					checkWebsiteMonitorAuthPass("correct horse"),
+				),
+			},
+			// turning hashing off stores the password in the state again
+			resource.TestStep{
+				Config: config("renamed", "correct horse", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("site24x7_website_monitor.test", "auth_pass", "correct horse"),
+					checkWebsiteMonitorAuthPass("correct horse"),
+				),
+			},
+			resource.TestStep{
+				Config:   config("renamed", "correct horse", false),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// checkWebsiteMonitorAuthPass checks the password the API stored, if the test runs against the fake API.
+func checkWebsiteMonitorAuthPass(want string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if testFakeServer == nil {
+			return nil
+		}
+		id := s.RootModule().Resources["site24x7_website_monitor.test"].Primary.ID
+		m, _ := testFakeServer.Get("monitors", id)
+		if got := m["auth_pass"]; got != want {
+			return fmt.Errorf("got auth_pass %q in API, want %q", got, want)
+		}
+		return nil
+	}
+}
+
+func TestWebsiteMonitorEventualConsistency(t *testing.T) {
+	if testFakeServer == nil {
+		t.Skip("needs the fake API")
+	}
+	testFakeServer.SetStaleReads(2)
+	defer testFakeServer.SetStaleReads(0)
+
+	testAccTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: checkMonitorsDestroyed("site24x7_website_monitor"),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: `
+					resource "site24x7_website_monitor" "test" {
+						display_name = "eventually"
+						website = "https://www.sourcegraph.com"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					checkMonitorExists("site24x7_website_monitor.test"),
+					resource.TestCheckResourceAttrSet("site24x7_website_monitor.test", "location_profile_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestWebsiteMonitorValidation(t *testing.T) {
+	const website = `website = "https://www.sourcegraph.com"`
+	testValidation(t, "site24x7_website_monitor", map[string]validationCase{
+		"check_frequency": {website + "\n check_frequency = 7", `check_frequency must be`},
+		"http_method":     {website + "\n http_method = \"FETCH\"", `http_method must be`},
+		"timeout":         {website + "\n timeout = 60", `timeout must be`},
+		"severity":        {website + "\n content_check {\n type = \"regex\"\n value = \"x\"\n severity = \"up\"\n }", `severity must be`},
+		"website":         {`website = "ftp://example.com"`, `website must be`},
+	})
+}
+
+func TestWebsiteMonitorStateUpgradeV0(t *testing.T) {
+	v0 := map[string]interface{}{
+		"display_name":       "test",
+		"action_ids":         []interface{}{"10", "11", "12"},
+		"action_alert_types": []interface{}{"2", "x"},
+	}
+	want := map[string]interface{}{
+		"display_name": "test",
+		"action": []interface{}{
+			map[string]interface{}{"id": "10", "alert_type": "trouble"},
+			map[string]interface{}{"id": "11", "alert_type": ""},
+			map[string]interface{}{"id": "12", "alert_type": ""},
+		},
+	}
+
+	got, err := websiteMonitorStateUpgradeV0(v0, nil)
 	if err != nil {
-		return err
+		t.Fatal(err)
 	}
-	if !exists {
-		return fmt.Errorf("monitor not found")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got state %#v, want %#v", got, want)
 	}
-	return nil
 }
 
-func checkWebsiteMonitorDestroyed(s *terraform.State) error {
-	rs := s.RootModule().Resources["site24x7_website_monitor.test"]
-	exists, err := fetchWebsiteMonitorExists(testAccProvider.Meta().(*http.Client), rs.Primary.ID)
+func TestWebsiteMonitorContentCheckConstraints(t *testing.T) {
+	const website = `website = "https://www.sourcegraph.com"`
+	testValidation(t, "site24x7_website_monitor", map[string]validationCase{
+		"duplicate type": {
+			attrs: website + `
+				content_check {
+					type = "keyword"
+					value = "a"
+				}
+				content_check {
+					type = "keyword"
+					value = "b"
+				}
+			`,
+			err: `content_check: only one check of type "keyword" is allowed`,
+		},
+		"mixed case sensitivity": {
+			attrs: website + `
+				content_check {
+					type = "keyword"
+					value = "a"
+					case_sensitive = true
+				}
+				content_check {
+					type = "regex"
+					value = "b"
+				}
+			`,
+			err: `content_check: case_sensitive must be the same for all checks`,
+		},
+	})
+}
+
+func TestWebsiteMonitorStateUpgradeV1(t *testing.T) {
+	v1 := map[string]interface{}{
+		"display_name":                "test",
+		"matching_keyword_value":      "Sourcegraph",
+		"matching_keyword_severity":   float64(0),
+		"unmatching_keyword_value":    " ",
+		"unmatching_keyword_severity": float64(2),
+		"match_regex_value":           "",
+		"match_regex_severity":        float64(2),
+		"match_case":                  true,
+	}
+	want := map[string]interface{}{
+		"display_name": "test",
+		"content_check": []interface{}{
+			map[string]interface{}{"type": "keyword", "value": "Sourcegraph", "severity": "down", "case_sensitive": true},
+		},
+	}
+
+	got, err := websiteMonitorStateUpgradeV1(v1, nil)
 	if err != nil {
-		return err
+		t.Fatal(err)
 	}
-	if exists {
-		return fmt.Errorf("monitor still exists")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got state %#v, want %#v", got, want)
 	}
-	return nil
 }